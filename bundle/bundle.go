@@ -0,0 +1,128 @@
+// Package bundle defines a reproducible election bundle, holding everything third parties need
+// to re-check the result of a poll: the ballots or the tally, the grade scale, the balancing strategy,
+// the median policy, the version of the library, the result, and SHA-256 hashes of all these.
+//
+//	b := &bundle.Bundle{Grades: grades, Ballots: ballots}
+//	err := b.Seal()             // deliberates, and fills the result and the hashes
+//	verification, err := b.Verify() // recomputes everything, and lists the mismatches
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+)
+
+// FormatVersion is the version of the bundle format written by Seal, and the only one Verify understands.
+const FormatVersion = 1
+
+// MedianPolicyLow is the low median, favoring contestation, as used by judgment.MajorityJudgment.
+// It is the only policy supported for now.
+const MedianPolicyLow = "low"
+
+// Bundle is a reproducible record of an election, usually stored as JSON.
+type Bundle struct {
+	Format         int                         `json:"format"`
+	LibraryVersion string                      `json:"libraryVersion"`      // judgment.Version when sealed
+	Grades         []string                    `json:"grades"`              // the grade scale, from worst to best
+	Proposals      []string                    `json:"proposals,omitempty"` // names of the proposals
+	Ballots        []judgment.Ballot           `json:"ballots,omitempty"`   // when present, the tally is counted from them
+	Tally          *judgment.PollTally         `json:"tally"`               // before balancing
+	Balancing      *judgment.BalancingStrategy `json:"balancing,omitempty"`
+	MedianPolicy   string                      `json:"medianPolicy"`
+	Result         *judgment.PollResult        `json:"result"`
+	Hashes         *Hashes                     `json:"hashes"`
+}
+
+// Hashes holds the hexadecimal SHA-256 of the JSON of some parts of the bundle.
+type Hashes struct {
+	Ballots string `json:"ballots,omitempty"`
+	Tally   string `json:"tally"`
+	Result  string `json:"result"`
+}
+
+// Seal counts the ballots into the tally if there are ballots, deliberates, and records the result and hashes.
+// It mutates the bundle.
+func (bundle *Bundle) Seal() (err error) {
+	if 0 == len(bundle.Grades) {
+		return errors.New("the bundle needs its grade scale")
+	}
+	if nil != bundle.Ballots {
+		bundle.Tally, err = judgment.TallyBallots(bundle.Ballots, bundle.countProposals(), len(bundle.Grades))
+		if nil != err {
+			return err
+		}
+	}
+	if nil == bundle.Tally {
+		return errors.New("the bundle needs ballots or a tally")
+	}
+	if 0 != len(bundle.Proposals) && len(bundle.Proposals) != len(bundle.Tally.Proposals) {
+		return fmt.Errorf("the bundle names %d proposals, but tallies %d",
+			len(bundle.Proposals), len(bundle.Tally.Proposals))
+	}
+	if "" == bundle.MedianPolicy {
+		bundle.MedianPolicy = MedianPolicyLow
+	}
+	bundle.Format = FormatVersion
+	bundle.LibraryVersion = judgment.Version
+
+	bundle.Result, err = bundle.deliberate()
+	if nil != err {
+		return err
+	}
+	bundle.Hashes, err = bundle.computeHashes()
+	return err
+}
+
+// countProposals reads the amount of proposals from the names, or else from the ballots.
+func (bundle *Bundle) countProposals() int {
+	if 0 != len(bundle.Proposals) {
+		return len(bundle.Proposals)
+	}
+	if 0 != len(bundle.Ballots) {
+		return len(bundle.Ballots[0])
+	}
+	return 0
+}
+
+// deliberate recomputes the result from a balanced copy of the tally.
+func (bundle *Bundle) deliberate() (_ *judgment.PollResult, err error) {
+	if MedianPolicyLow != bundle.MedianPolicy {
+		return nil, fmt.Errorf("unsupported median policy %q", bundle.MedianPolicy)
+	}
+	pollTally := bundle.Tally.Copy()
+	if err := pollTally.Balance(bundle.Balancing); nil != err {
+		return nil, err
+	}
+	deliberator := &judgment.MajorityJudgment{}
+	return deliberator.Deliberate(pollTally)
+}
+
+func (bundle *Bundle) computeHashes() (_ *Hashes, err error) {
+	hashes := &Hashes{}
+	if nil != bundle.Ballots {
+		if hashes.Ballots, err = hashJSON(bundle.Ballots); nil != err {
+			return nil, err
+		}
+	}
+	if hashes.Tally, err = hashJSON(bundle.Tally); nil != err {
+		return nil, err
+	}
+	if hashes.Result, err = hashJSON(bundle.Result); nil != err {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// hashJSON hashes the compact JSON encoding of v, which encoding/json keeps stable for our types.
+func hashJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if nil != err {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newBallotsBundle(t *testing.T) *Bundle {
+	bundle := &Bundle{
+		Grades:    []string{"reject", "poor", "fair", "good", "excellent"},
+		Proposals: []string{"Pizza", "Sushi", "Tacos"},
+		Ballots: []judgment.Ballot{
+			{4, 2, 0},
+			{3, 4, 1},
+			{1, 4, 2},
+			{0, 3, 4},
+			{2, 1, 3},
+		},
+	}
+	assert.NoError(t, bundle.Seal(), "Sealing should succeed")
+	return bundle
+}
+
+func TestBundle_Seal(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	assert.Equal(t, FormatVersion, bundle.Format)
+	assert.Equal(t, judgment.Version, bundle.LibraryVersion)
+	assert.Equal(t, MedianPolicyLow, bundle.MedianPolicy)
+	assert.Equal(t, []uint64{1, 1, 1, 1, 1}, bundle.Tally.Proposals[0].Tally)
+	assert.Equal(t, 1, bundle.Result.ProposalsSorted[0].Index, "Sushi should win")
+	assert.Len(t, bundle.Hashes.Ballots, 64)
+	assert.Len(t, bundle.Hashes.Tally, 64)
+	assert.Len(t, bundle.Hashes.Result, 64)
+}
+
+func TestBundle_SealTally(t *testing.T) {
+	bundle := &Bundle{
+		Grades:    []string{"bad", "fair", "good"},
+		Tally:     &judgment.PollTally{AmountOfJudges: 4, Proposals: []*judgment.ProposalTally{{Tally: []uint64{1, 1, 1}}, {Tally: []uint64{0, 2, 0}}}},
+		Balancing: &judgment.BalancingStrategy{Kind: judgment.BalancingStatic},
+	}
+	assert.NoError(t, bundle.Seal())
+	assert.Empty(t, bundle.Hashes.Ballots)
+	assert.Equal(t, []uint64{1, 1, 1}, bundle.Tally.Proposals[0].Tally, "The recorded tally should not be balanced")
+	assert.Equal(t, []uint64{2, 1, 1}, bundle.Result.Proposals[0].Tally.Tally)
+}
+
+func TestBundle_SealFailures(t *testing.T) {
+	assert.Error(t, (&Bundle{Tally: &judgment.PollTally{}}).Seal(), "Grades are required")
+	assert.Error(t, (&Bundle{Grades: []string{"bad"}}).Seal(), "A tally is required")
+	assert.Error(t, (&Bundle{Grades: []string{"bad"}, Proposals: []string{"A"}, Ballots: []judgment.Ballot{{1}}}).Seal())
+	assert.Error(t, (&Bundle{
+		Grades:    []string{"bad"},
+		Proposals: []string{"A", "B"},
+		Tally:     &judgment.PollTally{Proposals: []*judgment.ProposalTally{{Tally: []uint64{1}}}},
+	}).Seal(), "Names should match the tally")
+	assert.Error(t, (&Bundle{
+		Grades:       []string{"bad"},
+		Tally:        &judgment.PollTally{},
+		MedianPolicy: "high",
+	}).Seal(), "Only the low median is supported")
+}
+
+func TestBundle_JSON(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	data, err := json.Marshal(bundle)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"ballots":[[4,2,0],`)
+
+	decoded := &Bundle{}
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	verification, err := decoded.Verify()
+	assert.NoError(t, err)
+	assert.True(t, verification.OK(), "A decoded bundle should verify: %v", verification.Mismatches)
+}
@@ -0,0 +1,175 @@
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+)
+
+// Mismatch is a field of the bundle that differs from what we recomputed.
+type Mismatch struct {
+	Field      string `json:"field"` // like result.proposals[2].rank
+	Recorded   string `json:"recorded"`
+	Recomputed string `json:"recomputed"`
+}
+
+// String describes the mismatch for humans.
+func (mismatch Mismatch) String() string {
+	return fmt.Sprintf("%s: recorded %s, recomputed %s", mismatch.Field, mismatch.Recorded, mismatch.Recomputed)
+}
+
+// Verification lists what differs between a bundle and its recomputation.
+type Verification struct {
+	Mismatches []Mismatch `json:"mismatches"`
+	Warnings   []string   `json:"warnings"` // differences that do not invalidate the result, like the library version
+}
+
+// OK tells whether the bundle was verified without mismatches.
+func (verification *Verification) OK() bool {
+	return 0 == len(verification.Mismatches)
+}
+
+func (verification *Verification) mismatch(field string, recorded interface{}, recomputed interface{}) {
+	verification.Mismatches = append(verification.Mismatches, Mismatch{
+		Field:      field,
+		Recorded:   fmt.Sprint(recorded),
+		Recomputed: fmt.Sprint(recomputed),
+	})
+}
+
+// Verify recounts the ballots if any, recomputes the result and the hashes,
+// and compares them field by field with what the bundle records.
+// An error means the bundle could not be verified at all, for example when its format is unknown.
+func (bundle *Bundle) Verify() (_ *Verification, err error) {
+	if FormatVersion != bundle.Format {
+		return nil, fmt.Errorf("unsupported bundle format %d", bundle.Format)
+	}
+	if MedianPolicyLow != bundle.MedianPolicy {
+		return nil, fmt.Errorf("unsupported median policy %q", bundle.MedianPolicy)
+	}
+	if nil == bundle.Tally {
+		return nil, errors.New("the bundle has no tally")
+	}
+	if nil == bundle.Result {
+		return nil, errors.New("the bundle has no result")
+	}
+	for proposalIndex, proposalTally := range bundle.Tally.Proposals {
+		if nil == proposalTally {
+			return nil, fmt.Errorf("the tally of proposal #%d is null", proposalIndex)
+		}
+	}
+
+	verification := &Verification{Mismatches: []Mismatch{}, Warnings: []string{}}
+	if judgment.Version != bundle.LibraryVersion {
+		verification.Warnings = append(verification.Warnings, fmt.Sprintf(
+			"the bundle was sealed with version %s of the library, and verified with version %s",
+			bundle.LibraryVersion, judgment.Version))
+	}
+
+	if nil != bundle.Ballots {
+		// Ballots which cannot be counted, like tampered ones, are a failed check.
+		recounted, err := judgment.TallyBallots(bundle.Ballots, len(bundle.Tally.Proposals), len(bundle.Grades))
+		if nil != err {
+			verification.mismatch("ballots", "countable ballots", fmt.Sprintf("a failed count: %v", err))
+		} else {
+			compareTallies(verification, bundle.Tally, recounted)
+		}
+	}
+	if 0 != len(bundle.Proposals) && len(bundle.Proposals) != len(bundle.Tally.Proposals) {
+		verification.mismatch("proposals", fmt.Sprintf("%d names", len(bundle.Proposals)),
+			fmt.Sprintf("%d proposals", len(bundle.Tally.Proposals)))
+	}
+	for proposalIndex, proposalTally := range bundle.Tally.Proposals {
+		if len(bundle.Grades) != len(proposalTally.Tally) {
+			verification.mismatch(fmt.Sprintf("tally.proposals[%d].tally", proposalIndex),
+				fmt.Sprintf("%d grades", len(proposalTally.Tally)), fmt.Sprintf("%d grades", len(bundle.Grades)))
+		}
+	}
+
+	// A tally which cannot be deliberated, like an incoherent one, is a failed check.
+	result, err := bundle.deliberate()
+	if nil != err {
+		verification.mismatch("result", "a result", fmt.Sprintf("a failed deliberation: %v", err))
+	} else {
+		compareResults(verification, bundle.Result, result)
+	}
+
+	hashes, err := bundle.computeHashes()
+	if nil != err {
+		return nil, err
+	}
+	recorded := bundle.Hashes
+	if nil == recorded {
+		recorded = &Hashes{}
+	}
+	if recorded.Ballots != hashes.Ballots {
+		verification.mismatch("hashes.ballots", recorded.Ballots, hashes.Ballots)
+	}
+	if recorded.Tally != hashes.Tally {
+		verification.mismatch("hashes.tally", recorded.Tally, hashes.Tally)
+	}
+	if recorded.Result != hashes.Result {
+		verification.mismatch("hashes.result", recorded.Result, hashes.Result)
+	}
+
+	return verification, nil
+}
+
+func compareTallies(verification *Verification, recorded *judgment.PollTally, recounted *judgment.PollTally) {
+	if recorded.AmountOfJudges != recounted.AmountOfJudges {
+		verification.mismatch("tally.amountOfJudges", recorded.AmountOfJudges, recounted.AmountOfJudges)
+	}
+	for proposalIndex, proposalTally := range recounted.Proposals {
+		recordedTally := recorded.Proposals[proposalIndex].Tally
+		for grade, amount := range proposalTally.Tally {
+			if grade >= len(recordedTally) {
+				break // reported as a mismatch of the amount of grades
+			}
+			if recordedTally[grade] != amount {
+				verification.mismatch(fmt.Sprintf("tally.proposals[%d].tally[%d]", proposalIndex, grade),
+					recordedTally[grade], amount)
+			}
+		}
+	}
+}
+
+func compareResults(verification *Verification, recorded *judgment.PollResult, recomputed *judgment.PollResult) {
+	if len(recorded.Proposals) != len(recomputed.Proposals) {
+		verification.mismatch("result.proposals", fmt.Sprintf("%d proposals", len(recorded.Proposals)),
+			fmt.Sprintf("%d proposals", len(recomputed.Proposals)))
+		return
+	}
+	for proposalIndex, proposalResult := range recomputed.Proposals {
+		recordedResult := recorded.Proposals[proposalIndex]
+		field := fmt.Sprintf("result.proposals[%d]", proposalIndex)
+		if nil == recordedResult {
+			verification.mismatch(field, "null", "a result")
+			continue
+		}
+		if recordedResult.Index != proposalResult.Index {
+			verification.mismatch(field+".index", recordedResult.Index, proposalResult.Index)
+		}
+		if recordedResult.Rank != proposalResult.Rank {
+			verification.mismatch(field+".rank", recordedResult.Rank, proposalResult.Rank)
+		}
+		if recordedResult.Score != proposalResult.Score {
+			verification.mismatch(field+".score", recordedResult.Score, proposalResult.Score)
+		}
+	}
+	if len(recorded.ProposalsSorted) != len(recomputed.ProposalsSorted) {
+		verification.mismatch("result.proposalsSorted", fmt.Sprintf("%d proposals", len(recorded.ProposalsSorted)),
+			fmt.Sprintf("%d proposals", len(recomputed.ProposalsSorted)))
+		return
+	}
+	for position, proposalResult := range recomputed.ProposalsSorted {
+		recordedResult := recorded.ProposalsSorted[position]
+		if nil == recordedResult || recordedResult.Index != proposalResult.Index {
+			recordedIndex := "null"
+			if nil != recordedResult {
+				recordedIndex = fmt.Sprint(recordedResult.Index)
+			}
+			verification.mismatch(fmt.Sprintf("result.proposalsSorted[%d].index", position),
+				recordedIndex, proposalResult.Index)
+		}
+	}
+}
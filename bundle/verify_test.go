@@ -0,0 +1,130 @@
+package bundle
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func fieldsOf(verification *Verification) []string {
+	fields := []string{}
+	for _, mismatch := range verification.Mismatches {
+		fields = append(fields, mismatch.Field)
+	}
+	return fields
+}
+
+func TestBundle_Verify(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	verification, err := bundle.Verify()
+	assert.NoError(t, err, "Verification should run")
+	assert.True(t, verification.OK())
+	assert.Empty(t, verification.Warnings)
+}
+
+func TestBundle_VerifyTamperedBallots(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	bundle.Ballots[0][1] = 0
+	verification, err := bundle.Verify()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"tally.proposals[1].tally[0]",
+		"tally.proposals[1].tally[2]",
+		"hashes.ballots",
+	}, fieldsOf(verification))
+	assert.Equal(t, "tally.proposals[1].tally[0]: recorded 0, recomputed 1", verification.Mismatches[0].String())
+}
+
+func TestBundle_VerifyTamperedResult(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	bundle.Result.Proposals[0].Rank = 1
+	bundle.Result.Proposals[2].Score = "999"
+	bundle.Result.ProposalsSorted[0], bundle.Result.ProposalsSorted[1] = bundle.Result.ProposalsSorted[1], bundle.Result.ProposalsSorted[0]
+	verification, err := bundle.Verify()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"result.proposals[0].rank",
+		"result.proposals[2].score",
+		"result.proposalsSorted[0].index",
+		"result.proposalsSorted[1].index",
+		"hashes.result",
+	}, fieldsOf(verification))
+}
+
+func TestBundle_VerifyTamperedTally(t *testing.T) {
+	bundle := &Bundle{
+		Grades: []string{"bad", "fair", "good"},
+		Tally:  &judgment.PollTally{Proposals: []*judgment.ProposalTally{{Tally: []uint64{1, 1, 1}}, {Tally: []uint64{0, 2, 1}}}},
+	}
+	assert.NoError(t, bundle.Seal())
+	bundle.Tally.Proposals[1].Tally = []uint64{2, 0, 1}
+	bundle.LibraryVersion = "0.0.1"
+	verification, err := bundle.Verify()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"result.proposals[0].rank",
+		"result.proposals[1].rank",
+		"result.proposals[1].score",
+		"result.proposalsSorted[0].index",
+		"result.proposalsSorted[1].index",
+		"hashes.tally",
+	}, fieldsOf(verification))
+	assert.Len(t, verification.Warnings, 1, "Other versions of the library should be reported")
+}
+
+func TestBundle_VerifyIncoherentTally(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	bundle.Tally.AmountOfJudges = 1
+	verification, err := bundle.Verify()
+	assert.NoError(t, err, "Verification should run")
+	assert.False(t, verification.OK())
+	for _, mismatch := range verification.Mismatches {
+		if "result" == mismatch.Field {
+			assert.Contains(t, mismatch.Recomputed, "amount of judges")
+			return
+		}
+	}
+	t.Errorf("The failed deliberation should be a mismatch, got %v", fieldsOf(verification))
+}
+
+func TestBundle_VerifyUncountableBallots(t *testing.T) {
+	for _, ballot := range []judgment.Ballot{{1}, {1, 2, 5}} {
+		bundle := newBallotsBundle(t)
+		bundle.Ballots = append(bundle.Ballots, ballot)
+		verification, err := bundle.Verify()
+		assert.NoError(t, err, "Verification should run")
+		assert.False(t, verification.OK())
+		assert.Equal(t, "ballots", verification.Mismatches[0].Field, "Ballots should be countable: %v", ballot)
+		assert.Contains(t, fieldsOf(verification), "hashes.ballots")
+	}
+}
+
+func TestBundle_VerifyFailures(t *testing.T) {
+	bundle := newBallotsBundle(t)
+	bundle.Format = 2
+	_, err := bundle.Verify()
+	assert.Error(t, err)
+
+	bundle = newBallotsBundle(t)
+	bundle.MedianPolicy = "high"
+	_, err = bundle.Verify()
+	assert.Error(t, err, "Only the low median is supported")
+
+	bundle = newBallotsBundle(t)
+	bundle.Result = nil
+	_, err = bundle.Verify()
+	assert.Error(t, err)
+
+	bundle = newBallotsBundle(t)
+	bundle.Tally.Proposals[0] = nil
+	_, err = bundle.Verify()
+	assert.Error(t, err)
+
+	bundle = newBallotsBundle(t)
+	bundle.Grades = bundle.Grades[1:]
+	bundle.Ballots = nil
+	bundle.Hashes.Ballots = ""
+	verification, err := bundle.Verify()
+	assert.NoError(t, err)
+	assert.Contains(t, fieldsOf(verification), "tally.proposals[0].tally")
+}
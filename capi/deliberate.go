@@ -0,0 +1,90 @@
+// Command capi builds libmj, a C shared library exposing Majority Judgment deliberation to other runtimes.
+//
+//	go build -buildmode=c-shared -o libmj.so ./capi
+//
+// The stable ABI is declared in mj.h ; see the Makefile to build the library and its C test program.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+)
+
+// Status codes returned by mj_deliberate, keep them in sync with mj.h
+const (
+	statusOK               = 0
+	statusInvalidArgument  = 1
+	statusInvalidJSON      = 2
+	statusMishapedTally    = 3
+	statusIncoherentTally  = 4
+	statusUnbalancedTally  = 5
+	statusTooManyJudgments = 6
+	statusFailure          = 7
+)
+
+var errNullTally = errors.New("tally cannot be NULL")
+
+// abiVersion is bumped on any breaking change to mj.h
+const abiVersion = 1
+
+// errorResponse is the JSON written on failure, shaped like the errors of the HTTP API.
+type errorResponse struct {
+	Error errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// main is required by -buildmode=c-shared, but never called.
+func main() {}
+
+// deliberateJSON deliberates the PollTally in input and returns the PollResult as JSON.
+// On failure, it returns a status code and an errorResponse as JSON.
+// This is the whole logic of mj_deliberate, without cgo, so that we can test it.
+func deliberateJSON(input []byte) (int, []byte) {
+	pollTally := &judgment.PollTally{}
+	if err := json.Unmarshal(input, pollTally); nil != err {
+		return failure(statusInvalidJSON, "invalid_json", err)
+	}
+	for _, proposalTally := range pollTally.Proposals {
+		if nil == proposalTally {
+			return failure(statusInvalidJSON, "invalid_json", errors.New("tallies of proposals cannot be null"))
+		}
+	}
+
+	deliberator := &judgment.MajorityJudgment{}
+	result, err := deliberator.Deliberate(pollTally)
+	if nil != err {
+		switch {
+		case errors.Is(err, judgment.ErrMishapedTally):
+			return failure(statusMishapedTally, "mishaped_tally", err)
+		case errors.Is(err, judgment.ErrIncoherentTally):
+			return failure(statusIncoherentTally, "incoherent_tally", err)
+		case errors.Is(err, judgment.ErrUnbalancedTally):
+			return failure(statusUnbalancedTally, "unbalanced_tally", err)
+		case errors.Is(err, judgment.ErrTooManyJudgments):
+			return failure(statusTooManyJudgments, "too_many_judgments", err)
+		}
+		return failure(statusFailure, "deliberation_failure", err)
+	}
+
+	output, err := json.Marshal(result)
+	if nil != err {
+		return failure(statusFailure, "deliberation_failure", err)
+	}
+	return statusOK, output
+}
+
+// panicFailure turns a recovered panic into a failure, since panics cannot unwind through C.
+func panicFailure(recovered interface{}) (int, []byte) {
+	return failure(statusFailure, "internal_error", fmt.Errorf("unexpected panic: %v", recovered))
+}
+
+func failure(status int, code string, err error) (int, []byte) {
+	output, _ := json.Marshal(&errorResponse{Error: errorDetails{Code: code, Message: err.Error()}})
+	return status, output
+}
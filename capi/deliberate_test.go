@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDeliberateJSON(t *testing.T) {
+	status, output := deliberateJSON([]byte(`{"proposals": [{"tally": [2, 2, 2, 2, 2]}, {"tally": [2, 1, 1, 1, 5]}]}`))
+	assert.Equal(t, statusOK, status, string(output))
+
+	result := &judgment.PollResult{}
+	assert.NoError(t, json.Unmarshal(output, result), "Output should be a PollResult")
+	assert.Len(t, result.Proposals, 2)
+	assert.Equal(t, 1, result.ProposalsSorted[0].Index)
+	assert.Equal(t, 1, result.Proposals[1].Rank)
+}
+
+func TestDeliberateJSON_Failures(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		status int
+		code   string
+	}{
+		{"Invalid JSON", `{"proposals": [`, statusInvalidJSON, "invalid_json"},
+		{"Null proposal", `{"proposals": [null]}`, statusInvalidJSON, "invalid_json"},
+		{"Mishaped", `{"proposals": [{"tally": [2, 2]}, {"tally": [2]}]}`, statusMishapedTally, "mishaped_tally"},
+		{"Incoherent", `{"amountOfJudges": 3, "proposals": [{"tally": [2, 2]}]}`, statusIncoherentTally, "incoherent_tally"},
+		{"Unbalanced", `{"proposals": [{"tally": [2, 2]}, {"tally": [2, 1]}]}`, statusUnbalancedTally, "unbalanced_tally"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, output := deliberateJSON([]byte(tc.input))
+			assert.Equal(t, tc.status, status)
+
+			response := &errorResponse{}
+			assert.NoError(t, json.Unmarshal(output, response), "Output should be an error")
+			assert.Equal(t, tc.code, response.Error.Code)
+			assert.NotEmpty(t, response.Error.Message)
+		})
+	}
+}
+
+func TestPanicFailure(t *testing.T) {
+	status, output := panicFailure("index out of range")
+	assert.Equal(t, statusFailure, status)
+	response := &errorResponse{}
+	assert.NoError(t, json.Unmarshal(output, response), "Output should be an error")
+	assert.Equal(t, "internal_error", response.Error.Code)
+	assert.Contains(t, response.Error.Message, "index out of range")
+}
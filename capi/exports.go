@@ -0,0 +1,51 @@
+//go:build cgo
+// +build cgo
+
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+import "unsafe"
+
+// mj_abi_version returns the version of the ABI declared in mj.h.
+//
+//export mj_abi_version
+func mj_abi_version() C.int {
+	return C.int(abiVersion)
+}
+
+// mj_deliberate deliberates the PollTally JSON in tally, and stores in *output
+// the PollResult JSON, or an error JSON when the returned status is not MJ_OK.
+// *output is allocated with malloc, and must be released with mj_free.
+//
+//export mj_deliberate
+func mj_deliberate(tally *C.char, output **C.char) (returned C.int) {
+	if nil == output {
+		return C.int(statusInvalidArgument)
+	}
+	// A panic must not cross into C, where it would abort the host process.
+	defer func() {
+		if recovered := recover(); nil != recovered {
+			code, message := panicFailure(recovered)
+			*output = C.CString(string(message))
+			returned = C.int(code)
+		}
+	}()
+	if nil == tally {
+		status, message := failure(statusInvalidArgument, "invalid_argument", errNullTally)
+		*output = C.CString(string(message))
+		return C.int(status)
+	}
+	status, message := deliberateJSON([]byte(C.GoString(tally)))
+	*output = C.CString(string(message))
+	return C.int(status)
+}
+
+// mj_free releases a string allocated by this library.
+//
+//export mj_free
+func mj_free(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
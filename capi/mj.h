@@ -0,0 +1,50 @@
+/*
+ * libmj — Majority Judgment deliberation, as a C shared library.
+ *
+ * Build it with `make` in the capi directory, or with
+ *     go build -buildmode=c-shared -o libmj.so ./capi
+ *
+ * Tallies and results are exchanged as JSON, shaped like PollTally and PollResult
+ * in the judgment Go package:
+ *     {"amountOfJudges": 10, "proposals": [{"tally": [2, 2, 2, 2, 2]}, {"tally": [2, 1, 1, 1, 5]}]}
+ */
+#ifndef MJ_H
+#define MJ_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Version of this ABI, bumped on any breaking change. */
+#define MJ_ABI_VERSION 1
+
+/* Status codes returned by mj_deliberate. */
+#define MJ_OK                      0
+#define MJ_ERR_INVALID_ARGUMENT    1 /* tally or output is NULL */
+#define MJ_ERR_INVALID_JSON        2 /* tally is not a PollTally in JSON */
+#define MJ_ERR_MISHAPED_TALLY      3 /* proposals do not have the same amount of grades */
+#define MJ_ERR_INCOHERENT_TALLY    4 /* a proposal has more judgments than there are judges */
+#define MJ_ERR_UNBALANCED_TALLY    5 /* proposals do not have the same amount of judgments */
+#define MJ_ERR_TOO_MANY_JUDGMENTS  6 /* the scores would overflow */
+#define MJ_ERR_FAILURE             7 /* any other failure, like an unexpected panic */
+
+/* Returns the MJ_ABI_VERSION the library was built with. */
+int mj_abi_version(void);
+
+/*
+ * Deliberates the poll tally, a NUL-terminated JSON string.
+ * On MJ_OK, *output holds the result as JSON.
+ * Otherwise, *output holds {"error": {"code": "…", "message": "…"}},
+ * unless output itself is NULL.
+ * Release *output with mj_free.
+ */
+int mj_deliberate(const char *tally, char **output);
+
+/* Releases a string returned by this library. */
+void mj_free(char *s);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* MJ_H */
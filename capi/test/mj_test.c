@@ -0,0 +1,69 @@
+/*
+ * Exercises libmj through its C ABI ; run it with `make test`.
+ */
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "mj.h"
+
+static int failures = 0;
+
+static void expect(int condition, const char *description)
+{
+    if (!condition) {
+        fprintf(stderr, "FAIL: %s\n", description);
+        failures++;
+    }
+}
+
+static void expect_deliberation(const char *tally, int status, const char *needle)
+{
+    char *output = NULL;
+    int actual = mj_deliberate(tally, &output);
+
+    if (actual != status) {
+        fprintf(stderr, "FAIL: status %d instead of %d for %s\n", actual, status, tally);
+        failures++;
+    }
+    if (NULL == output || NULL == strstr(output, needle)) {
+        fprintf(stderr, "FAIL: %s not found in %s\n", needle, output ? output : "(null)");
+        failures++;
+    }
+    mj_free(output);
+}
+
+int main(void)
+{
+    char *output = NULL;
+
+    expect(MJ_ABI_VERSION == mj_abi_version(), "ABI version should match the header");
+
+    expect_deliberation(
+        "{\"proposals\": [{\"tally\": [2, 2, 2, 2, 2]}, {\"tally\": [2, 1, 1, 1, 5]}]}",
+        MJ_OK, "\"proposalsSorted\":[{\"index\":1,\"rank\":1");
+    expect_deliberation("{\"proposals\": [", MJ_ERR_INVALID_JSON, "\"code\":\"invalid_json\"");
+    expect_deliberation(
+        "{\"proposals\": [{\"tally\": [2, 2]}, {\"tally\": [2]}]}",
+        MJ_ERR_MISHAPED_TALLY, "\"code\":\"mishaped_tally\"");
+    expect_deliberation(
+        "{\"amountOfJudges\": 3, \"proposals\": [{\"tally\": [2, 2]}, {\"tally\": [2, 2]}]}",
+        MJ_ERR_INCOHERENT_TALLY, "\"code\":\"incoherent_tally\"");
+    expect_deliberation(
+        "{\"proposals\": [{\"tally\": [2, 2]}, {\"tally\": [2, 1]}]}",
+        MJ_ERR_UNBALANCED_TALLY, "\"code\":\"unbalanced_tally\"");
+    expect_deliberation(NULL, MJ_ERR_INVALID_ARGUMENT, "\"code\":\"invalid_argument\"");
+
+    expect(MJ_ERR_INVALID_ARGUMENT == mj_deliberate("{}", NULL), "NULL output should be refused");
+
+    expect(MJ_OK == mj_deliberate("{\"proposals\": []}", &output), "An empty poll should be deliberated");
+    mj_free(output);
+    mj_free(NULL);
+
+    if (failures) {
+        fprintf(stderr, "%d failure(s)\n", failures);
+        return EXIT_FAILURE;
+    }
+    printf("ok\n");
+    return EXIT_SUCCESS;
+}
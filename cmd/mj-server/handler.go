@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Codes of the structured error bodies, so that clients do not have to parse messages
+const (
+	errorCodeInvalidJSON         = "invalid_json"
+	errorCodeInvalidRequest      = "invalid_request"
+	errorCodeRequestTooLarge     = "request_too_large"
+	errorCodeMethodNotAllowed    = "method_not_allowed"
+	errorCodeNotFound            = "not_found"
+	errorCodeMishapedTally       = "mishaped_tally"
+	errorCodeIncoherentTally     = "incoherent_tally"
+	errorCodeUnbalancedTally     = "unbalanced_tally"
+	errorCodeTooManyJudgments    = "too_many_judgments"
+	errorCodeBalancingFailure    = "balancing_failure"
+	errorCodeDeliberationFailure = "deliberation_failure"
+)
+
+// maximumPaletteSize keeps palette generation cheap
+const maximumPaletteSize = 256
+
+// DeliberationRequest is the body of POST /deliberate and POST /balance.
+type DeliberationRequest struct {
+	Tally     *judgment.PollTally         `json:"tally"`
+	Balancing *judgment.BalancingStrategy `json:"balancing,omitempty"`
+}
+
+// ErrorResponse is the body of all our error responses.
+type ErrorResponse struct {
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails holds a stable code and a human message.
+type ErrorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// server holds the configuration of our handlers.
+type server struct {
+	maxBodyBytes int64
+}
+
+// newHandler returns the http.Handler of our API, which limits request bodies to maxBodyBytes.
+func newHandler(maxBodyBytes int64) http.Handler {
+	s := &server{maxBodyBytes: maxBodyBytes}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/deliberate", s.onlyMethod(http.MethodPost, s.handleDeliberate))
+	mux.HandleFunc("/analyze", s.onlyMethod(http.MethodPost, s.handleAnalyze))
+	mux.HandleFunc("/balance", s.onlyMethod(http.MethodPost, s.handleBalance))
+	mux.HandleFunc("/palette", s.onlyMethod(http.MethodGet, s.handlePalette))
+	mux.HandleFunc("/openapi.json", s.onlyMethod(http.MethodGet, s.handleOpenAPI))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, errorCodeNotFound, "no such endpoint, see /openapi.json")
+	})
+	return mux
+}
+
+func (s *server) onlyMethod(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if method != r.Method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, errorCodeMethodNotAllowed,
+				fmt.Sprintf("use %s on this endpoint", method))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *server) handleDeliberate(w http.ResponseWriter, r *http.Request) {
+	request := &DeliberationRequest{}
+	if !s.readJSON(w, r, request) {
+		return
+	}
+	if !validateTally(w, request.Tally) {
+		return
+	}
+	if err := request.Tally.Balance(request.Balancing); nil != err {
+		writeError(w, http.StatusUnprocessableEntity, errorCodeBalancingFailure, err.Error())
+		return
+	}
+
+	deliberator := &judgment.MajorityJudgment{}
+	result, err := deliberator.Deliberate(request.Tally)
+	if nil != err {
+		writeDeliberationError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	proposalTally := &judgment.ProposalTally{}
+	if !s.readJSON(w, r, proposalTally) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, proposalTally.Analyze())
+}
+
+func (s *server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	request := &DeliberationRequest{}
+	if !s.readJSON(w, r, request) {
+		return
+	}
+	if !validateTally(w, request.Tally) {
+		return
+	}
+	if nil == request.Balancing {
+		writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, "balancing is required")
+		return
+	}
+	if err := request.Tally.Balance(request.Balancing); nil != err {
+		writeError(w, http.StatusUnprocessableEntity, errorCodeBalancingFailure, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, request.Tally)
+}
+
+// handlePalette serves CreateDefaultPalette (of 7 colors by default), as JSON by default, or as hex, css or scss.
+func (s *server) handlePalette(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	amountOfColors := 7
+	if "" != query.Get("colors") {
+		parsed, err := strconv.Atoi(query.Get("colors"))
+		if nil != err {
+			parsed = -1
+		}
+		amountOfColors = parsed
+	}
+	if amountOfColors < 0 || amountOfColors > maximumPaletteSize {
+		writeError(w, http.StatusBadRequest, errorCodeInvalidRequest,
+			fmt.Sprintf("colors must be an integer between 0 and %d", maximumPaletteSize))
+		return
+	}
+	labels := []string{}
+	if "" != query.Get("labels") {
+		labels = strings.Split(query.Get("labels"), ",")
+	}
+
+	palette := judgment.CreateDefaultPalette(amountOfColors)
+	switch query.Get("format") {
+	case "", "json":
+		out, _ := judgment.DumpPaletteJSON(palette, labels)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(out)
+	case "hex":
+		writeText(w, "text/plain; charset=utf-8", judgment.DumpPaletteHexString(palette, ", ", "")+"\n")
+	case "css":
+		writeText(w, "text/css; charset=utf-8", judgment.DumpPaletteCSS(palette, labels, "mj-grade"))
+	case "scss":
+		writeText(w, "text/x-scss; charset=utf-8", judgment.DumpPaletteSCSS(palette, labels, "mj-grade"))
+	default:
+		writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, "format must be one of json, hex, css or scss")
+	}
+}
+
+func (s *server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeText(w, "application/json", openAPIDocument)
+}
+
+// readJSON decodes the body of the request into target, and writes the error response if it fails.
+func (s *server) readJSON(w http.ResponseWriter, r *http.Request, target interface{}) bool {
+	body := http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); nil != err {
+		// http.MaxBytesError is too recent for us
+		if strings.Contains(err.Error(), "request body too large") {
+			writeError(w, http.StatusRequestEntityTooLarge, errorCodeRequestTooLarge,
+				fmt.Sprintf("request bodies are limited to %d bytes", s.maxBodyBytes))
+			return false
+		}
+		writeError(w, http.StatusBadRequest, errorCodeInvalidJSON, err.Error())
+		return false
+	}
+	return true
+}
+
+func validateTally(w http.ResponseWriter, pollTally *judgment.PollTally) bool {
+	if nil == pollTally {
+		writeError(w, http.StatusBadRequest, errorCodeInvalidRequest, "tally is required")
+		return false
+	}
+	for proposalIndex, proposalTally := range pollTally.Proposals {
+		if nil == proposalTally {
+			writeError(w, http.StatusBadRequest, errorCodeInvalidRequest,
+				fmt.Sprintf("tally of proposal #%d is null", proposalIndex))
+			return false
+		}
+	}
+	return true
+}
+
+func writeDeliberationError(w http.ResponseWriter, err error) {
+	code := errorCodeDeliberationFailure
+	switch {
+	case errors.Is(err, judgment.ErrMishapedTally):
+		code = errorCodeMishapedTally
+	case errors.Is(err, judgment.ErrIncoherentTally):
+		code = errorCodeIncoherentTally
+	case errors.Is(err, judgment.ErrUnbalancedTally):
+		code = errorCodeUnbalancedTally
+	case errors.Is(err, judgment.ErrTooManyJudgments):
+		code = errorCodeTooManyJudgments
+	}
+	writeError(w, http.StatusUnprocessableEntity, code, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, &ErrorResponse{Error: ErrorDetails{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeText(w http.ResponseWriter, contentType string, text string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(text))
+}
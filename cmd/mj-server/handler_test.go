@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func request(t *testing.T, method string, path string, body string) (*http.Response, []byte) {
+	server := httptest.NewServer(newHandler(1024))
+	defer server.Close()
+
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	assert.NoError(t, err)
+	response, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	assert.NoError(t, err)
+	return response, content
+}
+
+func assertErrorCode(t *testing.T, expectedStatus int, expectedCode string, response *http.Response, body []byte) {
+	assert.Equal(t, expectedStatus, response.StatusCode, string(body))
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	errorResponse := &ErrorResponse{}
+	assert.NoError(t, json.Unmarshal(body, errorResponse))
+	assert.Equal(t, expectedCode, errorResponse.Error.Code)
+	assert.NotEmpty(t, errorResponse.Error.Message)
+}
+
+func TestDeliberate(t *testing.T) {
+	response, body := request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [
+		{"tally": [2, 2, 2, 2, 2]},
+		{"tally": [2, 1, 1, 1, 5]},
+		{"tally": [2, 1, 1, 2, 4]}
+	]}}`)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	result := &judgment.PollResult{}
+	assert.NoError(t, json.Unmarshal(body, result))
+	assert.Equal(t, 3, result.Proposals[0].Rank)
+	assert.Equal(t, 1, result.Proposals[1].Rank)
+	assert.Equal(t, 2, result.Proposals[2].Rank)
+}
+
+func TestDeliberate_WithBalancing(t *testing.T) {
+	response, body := request(t, http.MethodPost, "/deliberate", `{
+		"tally": {"amountOfJudges": 4, "proposals": [{"tally": [0, 1, 3]}, {"tally": [0, 2, 0]}]},
+		"balancing": {"kind": "static", "defaultGrade": 2}
+	}`)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	result := &judgment.PollResult{}
+	assert.NoError(t, json.Unmarshal(body, result))
+	assert.Equal(t, []uint64{0, 2, 2}, result.Proposals[1].Tally.Tally)
+}
+
+func TestDeliberate_Failures(t *testing.T) {
+	response, body := request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [{"tally": [1, 2]}, {"tally": [1]}]}}`)
+	assertErrorCode(t, http.StatusUnprocessableEntity, errorCodeMishapedTally, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [{"tally": [1, 2]}, {"tally": [1, 1]}]}}`)
+	assertErrorCode(t, http.StatusUnprocessableEntity, errorCodeUnbalancedTally, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": {"amountOfJudges": 1, "proposals": [{"tally": [1, 2]}]}}`)
+	assertErrorCode(t, http.StatusUnprocessableEntity, errorCodeIncoherentTally, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [{"tally": [1, 2]}]}, "balancing": {"kind": "mean"}}`)
+	assertErrorCode(t, http.StatusUnprocessableEntity, errorCodeBalancingFailure, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [null]}}`)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{}`)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": `)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidJSON, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tallies": []}`)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidJSON, response, body)
+
+	response, body = request(t, http.MethodPost, "/deliberate", `{"tally": {"proposals": [`+strings.Repeat(`{"tally": [1, 2]},`, 100)+`]}}`)
+	assertErrorCode(t, http.StatusRequestEntityTooLarge, errorCodeRequestTooLarge, response, body)
+
+	response, body = request(t, http.MethodGet, "/deliberate", ``)
+	assertErrorCode(t, http.StatusMethodNotAllowed, errorCodeMethodNotAllowed, response, body)
+	assert.Equal(t, http.MethodPost, response.Header.Get("Allow"))
+}
+
+func TestAnalyze(t *testing.T) {
+	response, body := request(t, http.MethodPost, "/analyze", `{"tally": [2, 1, 1, 1, 5]}`)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	analysis := &judgment.ProposalAnalysis{}
+	assert.NoError(t, json.Unmarshal(body, analysis))
+	assert.Equal(t, uint64(10), analysis.TotalSize)
+	assert.Equal(t, uint8(3), analysis.MedianGrade)
+	assert.Equal(t, 1, analysis.SecondGroupSign)
+}
+
+func TestBalance(t *testing.T) {
+	response, body := request(t, http.MethodPost, "/balance", `{
+		"tally": {"proposals": [{"tally": [1, 2, 3]}, {"tally": [0, 1, 0]}]},
+		"balancing": {"kind": "median"}
+	}`)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	pollTally := &judgment.PollTally{}
+	assert.NoError(t, json.Unmarshal(body, pollTally))
+	assert.Equal(t, uint64(6), pollTally.AmountOfJudges)
+	assert.Equal(t, []uint64{0, 6, 0}, pollTally.Proposals[1].Tally)
+
+	response, body = request(t, http.MethodPost, "/balance", `{"tally": {"proposals": []}}`)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+}
+
+func TestPalette(t *testing.T) {
+	response, body := request(t, http.MethodGet, "/palette?colors=2&labels=bad,good", ``)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	assert.JSONEq(t, `[{"grade": 0, "label": "bad", "color": "#df3222"}, {"grade": 1, "label": "good", "color": "#00a249"}]`, string(body))
+
+	response, body = request(t, http.MethodGet, "/palette", ``)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	entries := []judgment.PaletteEntry{}
+	assert.NoError(t, json.Unmarshal(body, &entries))
+	assert.Len(t, entries, 7)
+
+	response, body = request(t, http.MethodGet, "/palette?colors=3&format=css", ``)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(body))
+	assert.Contains(t, string(body), "--mj-grade-0: #df3222;")
+
+	response, body = request(t, http.MethodGet, "/palette?colors=-3", ``)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+	response, body = request(t, http.MethodGet, "/palette?colors=many", ``)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+	response, body = request(t, http.MethodGet, "/palette?format=pdf", ``)
+	assertErrorCode(t, http.StatusBadRequest, errorCodeInvalidRequest, response, body)
+}
+
+func TestNotFound(t *testing.T) {
+	response, body := request(t, http.MethodGet, "/nope", ``)
+	assertErrorCode(t, http.StatusNotFound, errorCodeNotFound, response, body)
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	response, body := request(t, http.MethodGet, "/openapi.json", ``)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	document := struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}{}
+	assert.NoError(t, json.Unmarshal(body, &document), "The OpenAPI document should be valid JSON")
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	routes := map[string]string{
+		"/deliberate":   "post",
+		"/analyze":      "post",
+		"/balance":      "post",
+		"/palette":      "get",
+		"/openapi.json": "get",
+	}
+	assert.Len(t, document.Paths, len(routes))
+	for path, method := range routes {
+		assert.Contains(t, document.Paths[path], method, path)
+	}
+
+	for _, code := range []string{
+		errorCodeInvalidJSON, errorCodeInvalidRequest, errorCodeRequestTooLarge, errorCodeMethodNotAllowed,
+		errorCodeNotFound, errorCodeMishapedTally, errorCodeIncoherentTally, errorCodeUnbalancedTally,
+		errorCodeTooManyJudgments, errorCodeBalancingFailure, errorCodeDeliberationFailure,
+	} {
+		assert.Contains(t, string(body), `"`+code+`"`, "Error codes should be documented")
+	}
+}
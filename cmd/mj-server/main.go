@@ -0,0 +1,31 @@
+// Command mj-server exposes Majority Judgment deliberation over an HTTP JSON API.
+//
+//	mj-server -addr :8080
+//
+// The API is described by the OpenAPI 3 document served at /openapi.json.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxBodyBytes := flag.Int64("max-body-bytes", 1<<20, "maximum size of request bodies, in bytes")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(*maxBodyBytes),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+
+	log.Printf("mj-server listening on %s", *addr)
+	log.Fatal(server.ListenAndServe())
+}
@@ -0,0 +1,226 @@
+package main
+
+// openAPIDocument describes our API ; keep it in sync with handler.go.
+// It is served at /openapi.json, and checked against the routes by our tests.
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Majority Judgment API",
+    "description": "Deliberation of polls using Majority Judgment.",
+    "version": "1.0.0",
+    "license": {"name": "MIT"}
+  },
+  "paths": {
+    "/deliberate": {
+      "post": {
+        "summary": "Rank the proposals of a poll",
+        "operationId": "deliberate",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/DeliberationRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Result of the deliberation",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PollResult"}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "413": {"$ref": "#/components/responses/TooLarge"},
+          "422": {"$ref": "#/components/responses/Unprocessable"}
+        }
+      }
+    },
+    "/analyze": {
+      "post": {
+        "summary": "Analyze the tally of a single proposal",
+        "operationId": "analyze",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ProposalTally"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Analysis of the proposal",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ProposalAnalysis"}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "413": {"$ref": "#/components/responses/TooLarge"}
+        }
+      }
+    },
+    "/balance": {
+      "post": {
+        "summary": "Balance the tally of a poll using a default judgment strategy",
+        "operationId": "balance",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/DeliberationRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "The balanced tally",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PollTally"}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "413": {"$ref": "#/components/responses/TooLarge"},
+          "422": {"$ref": "#/components/responses/Unprocessable"}
+        }
+      }
+    },
+    "/palette": {
+      "get": {
+        "summary": "Generate the default palette of colors for an amount of grades",
+        "operationId": "palette",
+        "parameters": [
+          {
+            "name": "colors", "in": "query", "description": "Amount of colors",
+            "schema": {"type": "integer", "minimum": 0, "maximum": 256, "default": 7}
+          },
+          {
+            "name": "format", "in": "query",
+            "schema": {"type": "string", "enum": ["json", "hex", "css", "scss"], "default": "json"}
+          },
+          {
+            "name": "labels", "in": "query", "description": "Comma-separated labels of the grades, from worst to best",
+            "schema": {"type": "string"}
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The palette",
+            "content": {
+              "application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/PaletteEntry"}}},
+              "text/plain": {"schema": {"type": "string"}},
+              "text/css": {"schema": {"type": "string"}},
+              "text/x-scss": {"schema": {"type": "string"}}
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "operationId": "openapi",
+        "responses": {
+          "200": {"description": "OpenAPI 3 document", "content": {"application/json": {"schema": {"type": "object"}}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "BadRequest": {
+        "description": "Invalid JSON or request",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}
+      },
+      "TooLarge": {
+        "description": "Request body is too large",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}
+      },
+      "Unprocessable": {
+        "description": "The tally cannot be balanced or deliberated",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}
+      }
+    },
+    "schemas": {
+      "ProposalTally": {
+        "type": "object",
+        "required": ["tally"],
+        "properties": {
+          "tally": {
+            "type": "array", "items": {"type": "integer", "minimum": 0},
+            "description": "Amount of judgments received for each grade, from worst grade to best grade"
+          }
+        }
+      },
+      "PollTally": {
+        "type": "object",
+        "required": ["proposals"],
+        "properties": {
+          "amountOfJudges": {"type": "integer", "minimum": 0, "description": "Guessed from the tallies when 0"},
+          "proposals": {"type": "array", "items": {"$ref": "#/components/schemas/ProposalTally"}}
+        }
+      },
+      "BalancingStrategy": {
+        "type": "object",
+        "required": ["kind"],
+        "properties": {
+          "kind": {"type": "string", "enum": ["none", "static", "median"]},
+          "defaultGrade": {"type": "integer", "minimum": 0, "maximum": 255, "description": "Used by the static strategy"}
+        }
+      },
+      "DeliberationRequest": {
+        "type": "object",
+        "required": ["tally"],
+        "properties": {
+          "tally": {"$ref": "#/components/schemas/PollTally"},
+          "balancing": {"$ref": "#/components/schemas/BalancingStrategy"}
+        }
+      },
+      "ProposalAnalysis": {
+        "type": "object",
+        "properties": {
+          "totalSize": {"type": "integer"},
+          "medianGrade": {"type": "integer"},
+          "medianGroupSize": {"type": "integer"},
+          "secondMedianGrade": {"type": "integer"},
+          "secondGroupSize": {"type": "integer"},
+          "secondGroupSign": {"type": "integer", "enum": [-1, 0, 1]},
+          "adhesionGroupGrade": {"type": "integer"},
+          "adhesionGroupSize": {"type": "integer"},
+          "contestationGroupGrade": {"type": "integer"},
+          "contestationGroupSize": {"type": "integer"}
+        }
+      },
+      "ProposalResult": {
+        "type": "object",
+        "properties": {
+          "index": {"type": "integer", "description": "Index of the proposal in the input tallies"},
+          "rank": {"type": "integer", "minimum": 1, "description": "Equal proposals share the same rank"},
+          "score": {"type": "string", "description": "Higher score lexicographically means better rank"},
+          "analysis": {"$ref": "#/components/schemas/ProposalAnalysis"},
+          "tally": {"$ref": "#/components/schemas/ProposalTally"}
+        }
+      },
+      "PollResult": {
+        "type": "object",
+        "properties": {
+          "proposals": {"type": "array", "items": {"$ref": "#/components/schemas/ProposalResult"}},
+          "proposalsSorted": {"type": "array", "items": {"$ref": "#/components/schemas/ProposalResult"}}
+        }
+      },
+      "PaletteEntry": {
+        "type": "object",
+        "properties": {
+          "grade": {"type": "integer"},
+          "label": {"type": "string"},
+          "color": {"type": "string", "example": "#df3222"}
+        }
+      },
+      "ErrorResponse": {
+        "type": "object",
+        "required": ["error"],
+        "properties": {
+          "error": {
+            "type": "object",
+            "required": ["code", "message"],
+            "properties": {
+              "code": {
+                "type": "string",
+                "enum": [
+                  "invalid_json", "invalid_request", "request_too_large", "method_not_allowed", "not_found",
+                  "mishaped_tally", "incoherent_tally", "unbalanced_tally", "too_many_judgments",
+                  "balancing_failure", "deliberation_failure"
+                ]
+              },
+              "message": {"type": "string"}
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io/ioutil"
+	"math"
+	"strconv"
+)
+
+// maximumUndoSteps bounds the memory used by the history of edits
+const maximumUndoSteps = 1000
+
+// maximumPendingDigits keeps typed counts within uint64
+const maximumPendingDigits = 18
+
+// maximumAmountOfGrades is what deliberation can count, in a uint8
+const maximumAmountOfGrades = math.MaxUint8
+
+// editor holds the state of the TUI: the tally being edited, the cursor, and the history of edits.
+// It knows nothing about terminals, so that we can test it.
+type editor struct {
+	tally    *judgment.PollTally
+	path     string
+	proposal int
+	grade    int
+	pending  string // digits typed in the current cell, not yet committed
+	history  []*judgment.PollTally
+	message  string
+	quit     bool
+}
+
+func newEditor(tally *judgment.PollTally, path string) *editor {
+	return &editor{tally: tally, path: path}
+}
+
+// handle applies a key press to the editor.
+func (e *editor) handle(k key) {
+	e.message = ""
+	switch k {
+	case keyUp, 'k':
+		e.move(-1, 0)
+	case keyDown, 'j':
+		e.move(1, 0)
+	case keyLeft, 'h':
+		e.move(0, -1)
+	case keyRight, 'l':
+		e.move(0, 1)
+	case '+', '=', ' ':
+		e.commitPending()
+		e.add(1)
+	case '-':
+		e.commitPending()
+		e.add(-1)
+	case keyEnter:
+		e.commitPending()
+	case keyBackspace:
+		if "" != e.pending {
+			e.pending = e.pending[:len(e.pending)-1]
+		}
+	case keyEscape:
+		e.pending = ""
+	case 'u':
+		e.pending = ""
+		if !e.undo() {
+			e.message = "Nothing to undo."
+		}
+	case 'a':
+		e.commitPending()
+		e.addProposal()
+	case 'x':
+		e.commitPending()
+		e.removeProposal()
+	case 'g':
+		e.commitPending()
+		e.addGrade()
+	case 'G':
+		e.commitPending()
+		e.removeGrade()
+	case 's':
+		e.commitPending()
+		if err := e.save(); nil != err {
+			e.message = fmt.Sprintf("Cannot save: %v", err)
+		} else {
+			e.message = fmt.Sprintf("Saved to %s.", e.path)
+		}
+	case 'r':
+		e.pending = ""
+		if err := e.load(); nil != err {
+			e.message = fmt.Sprintf("Cannot load: %v", err)
+		} else {
+			e.message = fmt.Sprintf("Loaded %s.", e.path)
+		}
+	case 'q', keyCtrlC:
+		e.quit = true
+	default:
+		if k >= '0' && k <= '9' && len(e.pending) < maximumPendingDigits {
+			e.pending += string(rune(k))
+		}
+	}
+}
+
+// result deliberates the tally, balanced with the worst grade since counting is usually in progress.
+func (e *editor) result() (*judgment.PollResult, error) {
+	balanced := e.tally.Copy()
+	balanced.AmountOfJudges = e.amountOfJudges()
+	err := balanced.Balance(&judgment.BalancingStrategy{Kind: judgment.BalancingStatic})
+	if nil != err {
+		return nil, err
+	}
+	deliberator := &judgment.MajorityJudgment{}
+	return deliberator.Deliberate(balanced)
+}
+
+// amountOfJudges is the amount declared in the tally, unless judgments kept coming in beyond it.
+func (e *editor) amountOfJudges() uint64 {
+	guessed := e.tally.Copy().GuessAmountOfJudges()
+	if e.tally.AmountOfJudges > guessed {
+		return e.tally.AmountOfJudges
+	}
+	return guessed
+}
+
+func (e *editor) countGrades() int {
+	if 0 == len(e.tally.Proposals) {
+		return 0
+	}
+	return len(e.tally.Proposals[0].Tally)
+}
+
+// move commits the typed digits, if any, like a spreadsheet would, and moves the cursor.
+func (e *editor) move(proposalDelta int, gradeDelta int) {
+	e.commitPending()
+	e.proposal += proposalDelta
+	e.grade += gradeDelta
+	e.clampCursor()
+}
+
+func (e *editor) clampCursor() {
+	e.proposal = clamp(e.proposal, 0, len(e.tally.Proposals)-1)
+	e.grade = clamp(e.grade, 0, e.countGrades()-1)
+}
+
+func (e *editor) commitPending() {
+	if "" == e.pending {
+		return
+	}
+	amount, err := strconv.ParseUint(e.pending, 10, 64)
+	e.pending = ""
+	if nil != err || !e.hasCell() {
+		return
+	}
+	e.snapshot()
+	e.tally.Proposals[e.proposal].Tally[e.grade] = amount
+}
+
+func (e *editor) add(delta int) {
+	if !e.hasCell() {
+		return
+	}
+	cell := &e.tally.Proposals[e.proposal].Tally[e.grade]
+	if delta < 0 && 0 == *cell {
+		return
+	}
+	e.snapshot()
+	if delta < 0 {
+		*cell--
+	} else {
+		*cell++
+	}
+}
+
+func (e *editor) addProposal() {
+	e.snapshot()
+	amountOfGrades := e.countGrades()
+	if 0 == amountOfGrades {
+		amountOfGrades = defaultAmountOfGrades
+	}
+	e.tally.Proposals = append(e.tally.Proposals, &judgment.ProposalTally{Tally: make([]uint64, amountOfGrades)})
+	e.proposal = len(e.tally.Proposals) - 1
+	e.clampCursor()
+}
+
+func (e *editor) removeProposal() {
+	if len(e.tally.Proposals) <= 1 {
+		e.message = "A poll needs at least one proposal."
+		return
+	}
+	e.snapshot()
+	e.tally.Proposals = append(e.tally.Proposals[:e.proposal], e.tally.Proposals[e.proposal+1:]...)
+	e.clampCursor()
+}
+
+func (e *editor) addGrade() {
+	if e.countGrades() >= maximumAmountOfGrades {
+		e.message = fmt.Sprintf("A poll cannot have more than %d grades.", maximumAmountOfGrades)
+		return
+	}
+	e.snapshot()
+	for _, proposalTally := range e.tally.Proposals {
+		proposalTally.Tally = append(proposalTally.Tally, 0)
+	}
+}
+
+// removeGrade removes the highest grade ; its judgments are lost, but the edit can be undone.
+func (e *editor) removeGrade() {
+	if e.countGrades() <= 1 {
+		e.message = "A poll needs at least one grade."
+		return
+	}
+	e.snapshot()
+	for _, proposalTally := range e.tally.Proposals {
+		proposalTally.Tally = proposalTally.Tally[:len(proposalTally.Tally)-1]
+	}
+	e.clampCursor()
+}
+
+func (e *editor) hasCell() bool {
+	return e.proposal < len(e.tally.Proposals) && e.grade < e.countGrades()
+}
+
+// snapshot records the tally before an edit, so that undo can restore it.
+func (e *editor) snapshot() {
+	e.history = append(e.history, e.tally.Copy())
+	if len(e.history) > maximumUndoSteps {
+		e.history = e.history[len(e.history)-maximumUndoSteps:]
+	}
+}
+
+func (e *editor) undo() bool {
+	if 0 == len(e.history) {
+		return false
+	}
+	e.tally = e.history[len(e.history)-1]
+	e.history = e.history[:len(e.history)-1]
+	e.clampCursor()
+	return true
+}
+
+func (e *editor) save() error {
+	data, err := json.MarshalIndent(e.tally, "", "  ")
+	if nil != err {
+		return err
+	}
+	return ioutil.WriteFile(e.path, append(data, '\n'), 0644)
+}
+
+// load replaces the tally with the one in our file ; this too can be undone.
+func (e *editor) load() error {
+	tally, err := readTally(e.path)
+	if nil != err {
+		return err
+	}
+	e.snapshot()
+	e.tally = tally
+	e.clampCursor()
+	return nil
+}
+
+// readTally reads a PollTally saved as JSON, and makes sure that we can edit it.
+func readTally(path string) (*judgment.PollTally, error) {
+	data, err := ioutil.ReadFile(path)
+	if nil != err {
+		return nil, err
+	}
+	tally := &judgment.PollTally{}
+	if err := json.Unmarshal(data, tally); nil != err {
+		return nil, err
+	}
+	if 0 == len(tally.Proposals) {
+		return nil, errors.New("the tally has no proposals")
+	}
+	for proposalIndex, proposalTally := range tally.Proposals {
+		if nil == proposalTally || 0 == len(proposalTally.Tally) {
+			return nil, fmt.Errorf("the tally of proposal #%d is empty", proposalIndex)
+		}
+		if len(proposalTally.Tally) != len(tally.Proposals[0].Tally) {
+			return nil, fmt.Errorf("%w: proposal #%d", judgment.ErrMishapedTally, proposalIndex)
+		}
+		if len(proposalTally.Tally) > maximumAmountOfGrades {
+			return nil, fmt.Errorf("the tally has more than %d grades", maximumAmountOfGrades)
+		}
+	}
+	return tally, nil
+}
+
+func clamp(value int, minimum int, maximum int) int {
+	if value > maximum {
+		value = maximum
+	}
+	if value < minimum {
+		value = minimum
+	}
+	return value
+}
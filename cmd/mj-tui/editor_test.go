@@ -0,0 +1,137 @@
+package main
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func press(e *editor, keys ...key) {
+	for _, k := range keys {
+		e.handle(k)
+	}
+}
+
+func TestEditor_Edit(t *testing.T) {
+	e := newEditor(newTally(2, 3), "tally.json")
+	press(e, '+', '+', keyRight, '+', keyDown, '4', '2', keyEnter, '-')
+	assert.Equal(t, []uint64{2, 1, 0}, e.tally.Proposals[0].Tally)
+	assert.Equal(t, []uint64{0, 41, 0}, e.tally.Proposals[1].Tally)
+
+	press(e, '7', keyLeft)
+	assert.Equal(t, []uint64{0, 7, 0}, e.tally.Proposals[1].Tally, "Moving should commit typed digits")
+	assert.Equal(t, 0, e.grade)
+
+	press(e, '-')
+	assert.Equal(t, uint64(0), e.tally.Proposals[1].Tally[0], "Counts should not go below zero")
+
+	press(e, '9', keyBackspace, '5', keyEscape, keyEnter)
+	assert.Equal(t, uint64(0), e.tally.Proposals[1].Tally[0], "Escape should cancel typed digits")
+
+	press(e, keyUp, keyUp, keyLeft, keyDown, keyDown, keyDown, 'l', 'l', 'l', 'l')
+	assert.Equal(t, 1, e.proposal, "Cursor should stay in the table")
+	assert.Equal(t, 2, e.grade, "Cursor should stay in the table")
+}
+
+func TestEditor_KeepsDeliberating(t *testing.T) {
+	e := newEditor(newTally(2, 3), "tally.json")
+	for i := 0; i < 5; i++ {
+		press(e, '+')
+		assert.NoError(t, render(ioutil.Discard, e, nil), "Rendering should succeed")
+		_, err := e.result()
+		assert.NoError(t, err, "Deliberation should succeed as judgments come in")
+	}
+	assert.Equal(t, uint64(0), e.tally.AmountOfJudges, "Rendering should not set the amount of judges")
+}
+
+func TestEditor_DeclaredAmountOfJudges(t *testing.T) {
+	tally := newTally(2, 3)
+	tally.AmountOfJudges = 4
+	e := newEditor(tally, "tally.json")
+	press(e, '+', keyDown, keyRight, '+', '+')
+	result, err := e.result()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), result.Proposals[0].Tally.CountJudgments(), "Missing judgments should fill the declared electorate")
+	assert.Equal(t, 1, result.Proposals[1].Rank)
+
+	press(e, '5', keyEnter)
+	result, err = e.result()
+	assert.NoError(t, err, "Judgments beyond the declared amount should not break deliberation")
+	assert.Equal(t, uint64(5), result.Proposals[0].Tally.CountJudgments())
+}
+
+func TestEditor_Undo(t *testing.T) {
+	e := newEditor(newTally(2, 3), "tally.json")
+	press(e, '+', '+', 'a', 'g', 'x')
+	assert.Len(t, e.tally.Proposals, 2)
+	assert.Len(t, e.tally.Proposals[0].Tally, 4)
+
+	press(e, 'u', 'u')
+	assert.Len(t, e.tally.Proposals, 3)
+	assert.Len(t, e.tally.Proposals[0].Tally, 3)
+	press(e, 'u', 'u')
+	assert.Equal(t, []uint64{1, 0, 0}, e.tally.Proposals[0].Tally)
+	press(e, 'u', 'u')
+	assert.Equal(t, []uint64{0, 0, 0}, e.tally.Proposals[0].Tally)
+	assert.Equal(t, "Nothing to undo.", e.message)
+}
+
+func TestEditor_ShapeLimits(t *testing.T) {
+	e := newEditor(newTally(1, 1), "tally.json")
+	press(e, 'x')
+	assert.Len(t, e.tally.Proposals, 1)
+	assert.NotEmpty(t, e.message)
+	press(e, 'G')
+	assert.Len(t, e.tally.Proposals[0].Tally, 1)
+	assert.NotEmpty(t, e.message)
+	assert.Empty(t, e.history, "Refused edits should not be undoable")
+
+	e = newEditor(newTally(1, maximumAmountOfGrades), "tally.json")
+	press(e, 'g')
+	assert.Len(t, e.tally.Proposals[0].Tally, 255, "256 grades would wrap around")
+	assert.Contains(t, e.message, "255 grades")
+}
+
+func TestEditor_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tally.json")
+	e := newEditor(newTally(2, 3), path)
+	press(e, '3', 's')
+	assert.Equal(t, "Saved to "+path+".", e.message)
+
+	press(e, '+', 'a', 'r')
+	assert.Equal(t, "Loaded "+path+".", e.message)
+	assert.Len(t, e.tally.Proposals, 2)
+	assert.Equal(t, []uint64{3, 0, 0}, e.tally.Proposals[0].Tally)
+
+	press(e, 'u')
+	assert.Len(t, e.tally.Proposals, 3, "Loading should be undoable")
+}
+
+func TestReadTally_Failures(t *testing.T) {
+	directory := t.TempDir()
+	for name, content := range map[string]string{
+		"invalid":  `{"proposals": [`,
+		"empty":    `{"proposals": []}`,
+		"null":     `{"proposals": [null]}`,
+		"mishaped": `{"proposals": [{"tally": [1, 2]}, {"tally": [1]}]}`,
+		"too many": `{"proposals": [{"tally": [` + strings.Repeat("0, ", 255) + `0]}]}`,
+	} {
+		path := filepath.Join(directory, name+".json")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		_, err := readTally(path)
+		assert.Error(t, err, name)
+	}
+	_, err := readTally(filepath.Join(directory, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestEditor_Quit(t *testing.T) {
+	for _, k := range []key{'q', keyCtrlC} {
+		e := newEditor(&judgment.PollTally{}, "tally.json")
+		press(e, '+', k)
+		assert.True(t, e.quit)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import "unicode/utf8"
+
+// key is either a printable rune, or one of the special keys below.
+type key rune
+
+// Special keys, negative so that they never collide with runes
+const (
+	keyUnknown key = -(iota + 1)
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyBackspace
+	keyEscape
+	keyCtrlC
+)
+
+// parseKeys decodes what a terminal in raw mode sends when keys are pressed.
+// Both the CSI (ESC [) and SS3 (ESC O) flavors of arrow keys are understood.
+func parseKeys(input []byte) []key {
+	keys := make([]key, 0, len(input))
+	for len(input) > 0 {
+		switch input[0] {
+		case 0x1b:
+			if len(input) >= 3 && ('[' == input[1] || 'O' == input[1]) {
+				keys = append(keys, arrowKey(input[2]))
+				input = input[3:]
+				continue
+			}
+			keys = append(keys, keyEscape)
+			input = input[1:]
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+			input = input[1:]
+		case 0x7f, 0x08:
+			keys = append(keys, keyBackspace)
+			input = input[1:]
+		case 0x03:
+			keys = append(keys, keyCtrlC)
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			if utf8.RuneError == r || r < 0x20 {
+				keys = append(keys, keyUnknown)
+			} else {
+				keys = append(keys, key(r))
+			}
+			input = input[size:]
+		}
+	}
+	return keys
+}
+
+func arrowKey(final byte) key {
+	switch final {
+	case 'A':
+		return keyUp
+	case 'B':
+		return keyDown
+	case 'C':
+		return keyRight
+	case 'D':
+		return keyLeft
+	}
+	return keyUnknown
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []key{keyUp, keyDown, keyRight, keyLeft}, parseKeys([]byte("\x1b[A\x1b[B\x1b[C\x1b[D")))
+	assert.Equal(t, []key{keyUp, keyLeft}, parseKeys([]byte("\x1bOA\x1bOD")), "SS3 arrows")
+	assert.Equal(t, []key{'4', '2', keyEnter, keyEnter}, parseKeys([]byte("42\r\n")))
+	assert.Equal(t, []key{keyBackspace, keyBackspace, keyCtrlC, keyEscape}, parseKeys([]byte("\x7f\x08\x03\x1b")))
+	assert.Equal(t, []key{'é', keyUnknown, 'u'}, parseKeys([]byte("é\x01u")))
+	assert.Equal(t, []key{keyUnknown}, parseKeys([]byte("\x1b[Z")))
+	assert.Empty(t, parseKeys([]byte{}))
+}
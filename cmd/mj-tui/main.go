@@ -0,0 +1,132 @@
+// Command mj-tui is a terminal UI to type a tally as judgments are counted,
+// and watch the Majority Judgment ranking and merit profiles update instantly.
+//
+//	mj-tui -proposals 4 -grades 6 -names "Pizza,Sushi,Tacos,Salad" tally.json
+//
+// The tally is loaded from the file if it exists, and saved to it with the s key.
+// Missing judgments count as the worst grade while counting is in progress.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io"
+	"os"
+	"strings"
+)
+
+// Default shape of a new tally
+const (
+	defaultAmountOfProposals = 2
+	defaultAmountOfGrades    = 5
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin *os.File, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mj-tui", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	amountOfProposals := flags.Int("proposals", defaultAmountOfProposals, "amount of proposals of a new tally")
+	amountOfGrades := flags.Int("grades", defaultAmountOfGrades, "amount of grades of a new tally")
+	names := flags.String("names", "", "comma-separated names of the proposals")
+	gradeNames := flags.String("grade-names", "", "comma-separated names of the grades, from worst to best")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mj-tui [flags] [file]\n\n")
+		fmt.Fprintf(stderr, "Edits the tally in file, tally.json by default, and deliberates it live.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); nil != err {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 1 || *amountOfProposals < 1 {
+		flags.Usage()
+		return 2
+	}
+	if *amountOfGrades < 1 || *amountOfGrades > maximumAmountOfGrades {
+		fmt.Fprintf(stderr, "mj-tui: -grades must be between 1 and %d\n", maximumAmountOfGrades)
+		return 2
+	}
+
+	path := "tally.json"
+	if 1 == flags.NArg() {
+		path = flags.Arg(0)
+	}
+	tally, err := readTally(path)
+	if os.IsNotExist(err) {
+		tally = newTally(*amountOfProposals, *amountOfGrades)
+	} else if nil != err {
+		fmt.Fprintf(stderr, "mj-tui: %s: %v\n", path, err)
+		return 1
+	}
+
+	options := &judgment.ReportOptions{}
+	if "" != *names {
+		options.ProposalNames = splitNames(*names)
+	}
+	if "" != *gradeNames {
+		options.GradeNames = splitNames(*gradeNames)
+	}
+
+	restore, err := enableRawMode(stdin)
+	if nil != err {
+		fmt.Fprintf(stderr, "mj-tui: cannot use this terminal: %v\n", err)
+		return 1
+	}
+	fmt.Fprint(stdout, ansiAlternateScreen+ansiHideCursor)
+	err = loop(newEditor(tally, path), options, stdin, stdout)
+	fmt.Fprint(stdout, ansiShowCursor+ansiPrimaryScreen)
+	_ = restore()
+	if nil != err {
+		fmt.Fprintf(stderr, "mj-tui: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// loop renders the editor after each read of the input, until it quits.
+func loop(e *editor, options *judgment.ReportOptions, input io.Reader, output io.Writer) error {
+	buffer := make([]byte, 64)
+	for {
+		if err := render(output, e, options); nil != err {
+			return err
+		}
+		if e.quit {
+			return nil
+		}
+		n, err := input.Read(buffer)
+		for _, k := range parseKeys(buffer[:n]) {
+			if !e.quit {
+				e.handle(k)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if nil != err {
+			return err
+		}
+	}
+}
+
+func newTally(amountOfProposals int, amountOfGrades int) *judgment.PollTally {
+	tally := &judgment.PollTally{Proposals: make([]*judgment.ProposalTally, 0, amountOfProposals)}
+	for i := 0; i < amountOfProposals; i++ {
+		tally.Proposals = append(tally.Proposals, &judgment.ProposalTally{Tally: make([]uint64, amountOfGrades)})
+	}
+	return tally
+}
+
+func splitNames(s string) []string {
+	names := strings.Split(s, ",")
+	for nameIndex := range names {
+		names[nameIndex] = strings.TrimSpace(names[nameIndex])
+	}
+	return names
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLoop(t *testing.T) {
+	e := newEditor(newTally(2, 3), "tally.json")
+	output := &strings.Builder{}
+	assert.NoError(t, loop(e, &judgment.ReportOptions{}, strings.NewReader("++\x1b[B+q+"), output))
+	assert.True(t, e.quit)
+	assert.Equal(t, []uint64{2, 0, 0}, e.tally.Proposals[0].Tally)
+	assert.Equal(t, []uint64{1, 0, 0}, e.tally.Proposals[1].Tally, "Keys after q should be ignored")
+	assert.Equal(t, 2, strings.Count(output.String(), ansiClear), "Screen should be drawn before and after input")
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{{"-grades", "0"}, {"-grades", "256"}} {
+		stderr := &strings.Builder{}
+		assert.Equal(t, 2, run(args, nil, &strings.Builder{}, stderr), args)
+		assert.Contains(t, stderr.String(), "between 1 and 255")
+	}
+}
+
+func TestLoop_EOF(t *testing.T) {
+	e := newEditor(newTally(1, 2), "tally.json")
+	assert.NoError(t, loop(e, nil, strings.NewReader(""), &strings.Builder{}))
+	assert.False(t, e.quit)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// helpLine lists the keys, see editor.handle
+const helpLine = "arrows/hjkl move · 0-9 type a count, Enter to set · +/- count · u undo · " +
+	"a/x add/remove proposal · g/G add/remove grade · s save · r reload · q quit"
+
+// render draws the whole screen, with lines ending in \r\n since the terminal is in raw mode.
+func render(w io.Writer, e *editor, options *judgment.ReportOptions) error {
+	result, err := e.result()
+
+	nameWidth := len("Proposal")
+	for proposalIndex := range e.tally.Proposals {
+		nameWidth = maxInt(nameWidth, len([]rune(options.ProposalName(proposalIndex))))
+	}
+	cellWidths := make([]int, e.countGrades())
+	for grade := range cellWidths {
+		cellWidths[grade] = maxInt(5, len([]rune(options.GradeName(uint8(grade)))))
+	}
+	medianWidth := len("Median")
+	for _, width := range cellWidths {
+		medianWidth = maxInt(medianWidth, width)
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(ansiClear)
+	fmt.Fprintf(b, "%smj-tui%s · %s\r\n\r\n", ansiBold, ansiReset, e.path)
+
+	b.WriteString(pad("Proposal", nameWidth, false))
+	for grade, width := range cellWidths {
+		b.WriteString(" " + pad(options.GradeName(uint8(grade)), width, true))
+	}
+	fmt.Fprintf(b, "  %s  %s  %s\r\n", pad("Rank", 4, true), pad("Median", medianWidth, false), "Merit profile")
+
+	for proposalIndex, proposalTally := range e.tally.Proposals {
+		b.WriteString(pad(options.ProposalName(proposalIndex), nameWidth, false))
+		for grade, width := range cellWidths {
+			cell := strconv.FormatUint(proposalTally.Tally[grade], 10)
+			isCursor := proposalIndex == e.proposal && grade == e.grade
+			if isCursor && "" != e.pending {
+				cell = e.pending + "_"
+			}
+			cell = pad(cell, width, true)
+			if isCursor {
+				cell = ansiReverse + cell + ansiReset
+			}
+			b.WriteString(" " + cell)
+		}
+		rank, median := "-", "-"
+		if nil != result {
+			proposalResult := result.Proposals[proposalIndex]
+			rank = strconv.Itoa(proposalResult.Rank)
+			if isTied(result, proposalResult) {
+				rank += "="
+			}
+			median = options.GradeName(proposalResult.Analysis.MedianGrade)
+		}
+		fmt.Fprintf(b, "  %s  %s  %s\r\n", pad(rank, 4, true), pad(median, medianWidth, false),
+			judgment.MeritBar(proposalTally, judgment.MeritBarWidth))
+	}
+
+	b.WriteString("\r\n")
+	if nil != err {
+		fmt.Fprintf(b, "Cannot deliberate: %v\r\n", err)
+	} else {
+		fmt.Fprintf(b, "%d judges, missing judgments count as %s.\r\n",
+			e.amountOfJudges(), options.GradeName(0))
+	}
+	fmt.Fprintf(b, "%s\r\n\r\n%s", e.message, helpLine)
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// pad aligns s within width runes ; our labels are not expected to hold wide characters.
+func pad(s string, width int, alignRight bool) string {
+	padding := width - len([]rune(s))
+	if padding <= 0 {
+		return s
+	}
+	if alignRight {
+		return strings.Repeat(" ", padding) + s
+	}
+	return s + strings.Repeat(" ", padding)
+}
+
+func isTied(result *judgment.PollResult, proposalResult *judgment.ProposalResult) bool {
+	for _, other := range result.Proposals {
+		if other != proposalResult && other.Rank == proposalResult.Rank {
+			return true
+		}
+	}
+	return false
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
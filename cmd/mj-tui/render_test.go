@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	e := newEditor(&judgment.PollTally{Proposals: []*judgment.ProposalTally{
+		{Tally: []uint64{1, 2, 3}},
+		{Tally: []uint64{3, 2, 0}},
+	}}, "tally.json")
+	press(e, keyRight, '1', '2')
+	options := &judgment.ReportOptions{ProposalNames: []string{"Pizza", "Sushi"}, GradeNames: []string{"bad", "fair", "good"}}
+
+	b := &strings.Builder{}
+	assert.NoError(t, render(b, e, options))
+	screen := b.String()
+	assert.True(t, strings.HasPrefix(screen, ansiClear))
+	lines := strings.Split(screen, "\r\n")
+	assert.Contains(t, lines[2], "good")
+	assert.Contains(t, lines[3], "Pizza")
+	assert.Contains(t, lines[3], ansiReverse+"  12_"+ansiReset, "Typed digits should show in the cursor cell")
+	assert.Regexp(t, `\s1\s+fair\s`, lines[3])
+	assert.Regexp(t, `\s2\s+bad\s`, lines[4])
+	assert.Contains(t, lines[6], "6 judges, missing judgments count as bad.")
+	assert.Contains(t, screen, helpLine)
+}
+
+func TestRender_DeliberationFailure(t *testing.T) {
+	e := newEditor(&judgment.PollTally{Proposals: []*judgment.ProposalTally{
+		{Tally: []uint64{math.MaxInt64, 1}},
+	}}, "tally.json")
+	b := &strings.Builder{}
+	assert.NoError(t, render(b, e, nil))
+	assert.Contains(t, b.String(), "Cannot deliberate:")
+	assert.Contains(t, b.String(), "Proposal A")
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ANSI escape sequences we use to draw the screen
+const (
+	ansiClear           = "\x1b[H\x1b[2J"
+	ansiReverse         = "\x1b[7m"
+	ansiBold            = "\x1b[1m"
+	ansiReset           = "\x1b[0m"
+	ansiHideCursor      = "\x1b[?25l"
+	ansiShowCursor      = "\x1b[?25h"
+	ansiAlternateScreen = "\x1b[?1049h"
+	ansiPrimaryScreen   = "\x1b[?1049l"
+)
+
+// enableRawMode puts the terminal of tty in raw mode, and returns a func restoring its previous state.
+// We shell out to stty so that we only depend on the standard library.
+func enableRawMode(tty *os.File) (restore func() error, err error) {
+	state, err := stty(tty, "-g")
+	if nil != err {
+		return nil, err
+	}
+	if _, err := stty(tty, "raw", "-echo"); nil != err {
+		return nil, err
+	}
+	return func() error {
+		_, err := stty(tty, strings.TrimSpace(state))
+		return err
+	}, nil
+}
+
+func stty(tty *os.File, args ...string) (string, error) {
+	command := exec.Command("stty", args...)
+	command.Stdin = tty
+	output, err := command.Output()
+	return string(output), err
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Input formats of the poll tally
+const (
+	formatAuto    = "auto"
+	formatJSON    = "json"
+	formatCSV     = "csv"
+	formatCompact = "compact"
+)
+
+// parsedInput is a PollTally, plus the names found along the way, if any.
+type parsedInput struct {
+	Tally         *judgment.PollTally
+	ProposalNames []string
+	GradeNames    []string
+}
+
+// guessFormat picks an input format from the file extension, or else from the content itself.
+func guessFormat(path string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".csv":
+		return formatCSV
+	case ".txt", ".mj":
+		return formatCompact
+	}
+
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return formatJSON
+	}
+	if strings.Contains(trimmed, ",") {
+		return formatCSV
+	}
+	return formatCompact
+}
+
+func parseInput(format string, content []byte) (*parsedInput, error) {
+	switch format {
+	case formatJSON:
+		return parseJSON(content)
+	case formatCSV:
+		return parseCSV(content)
+	case formatCompact:
+		return parseCompact(content)
+	}
+	return nil, fmt.Errorf("unknown input format %q", format)
+}
+
+// parseJSON reads a PollTally as JSON, or a bare array of proposals' tallies:
+//
+//	{"amountOfJudges": 10, "proposals": [{"tally": [2, 2, 2, 2, 2]}, {"tally": [2, 1, 1, 1, 5]}]}
+//	[[2, 2, 2, 2, 2], [2, 1, 1, 1, 5]]
+func parseJSON(content []byte) (*parsedInput, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "[") {
+		var rawTallies [][]uint64
+		if err := json.Unmarshal(content, &rawTallies); nil != err {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return &parsedInput{Tally: pollTallyFromRaw(rawTallies)}, nil
+	}
+
+	pollTally := &judgment.PollTally{}
+	if err := json.Unmarshal(content, pollTally); nil != err {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	for proposalIndex, proposalTally := range pollTally.Proposals {
+		if nil == proposalTally {
+			return nil, fmt.Errorf("invalid JSON: proposal #%d is null", proposalIndex)
+		}
+	}
+	return &parsedInput{Tally: pollTally}, nil
+}
+
+// parseCSV reads one proposal per row, and one grade per column, from "worst" to "best".
+// The first column may hold the proposals' names, and the first row may hold the grades' names.
+//
+//	proposal,reject,poor,fair,good,excellent
+//	Pizza,2,2,2,2,2
+//	Sushi,2,1,1,1,5
+func parseCSV(content []byte) (*parsedInput, error) {
+	reader := csv.NewReader(strings.NewReader(string(content)))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if nil != err {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	input := &parsedInput{}
+	rawTallies := make([][]uint64, 0, len(records))
+	hasNames := false
+	for recordIndex, record := range records {
+		if 1 == len(record) && "" == strings.TrimSpace(record[0]) {
+			continue // blank line
+		}
+		if 0 == recordIndex && !isNumeric(record[len(record)-1]) {
+			input.GradeNames = record
+			continue
+		}
+
+		if 0 == len(rawTallies) && !isNumeric(record[0]) {
+			hasNames = true
+		}
+		if hasNames {
+			input.ProposalNames = append(input.ProposalNames, strings.TrimSpace(record[0]))
+			record = record[1:]
+		}
+
+		rawTally := make([]uint64, 0, len(record))
+		for columnIndex, cell := range record {
+			amount, err := strconv.ParseUint(strings.TrimSpace(cell), 10, 64)
+			if nil != err {
+				return nil, fmt.Errorf("invalid CSV: row %d, column %d: %q is not an amount of judgments",
+					recordIndex+1, columnIndex+1, cell)
+			}
+			rawTally = append(rawTally, amount)
+		}
+		rawTallies = append(rawTallies, rawTally)
+	}
+	// The header cell above the proposals' names is not a grade
+	if len(rawTallies) > 0 && len(input.GradeNames) == len(rawTallies[0])+1 {
+		input.GradeNames = input.GradeNames[1:]
+	}
+
+	input.Tally = pollTallyFromRaw(rawTallies)
+	return input, nil
+}
+
+// parseCompact reads the compact notation used in our docs, where grades are separated by dashes
+// and proposals by underscores or newlines: 2-2-2-2-2_2-1-1-1-5_2-1-1-2-4
+func parseCompact(content []byte) (*parsedInput, error) {
+	proposals := strings.FieldsFunc(string(content), func(r rune) bool {
+		return '_' == r || '\n' == r || '\r' == r || ';' == r
+	})
+
+	rawTallies := make([][]uint64, 0, len(proposals))
+	for proposalIndex, proposal := range proposals {
+		proposal = strings.TrimSpace(proposal)
+		if "" == proposal {
+			continue
+		}
+		grades := strings.Split(proposal, "-")
+		rawTally := make([]uint64, 0, len(grades))
+		for _, grade := range grades {
+			amount, err := strconv.ParseUint(strings.TrimSpace(grade), 10, 64)
+			if nil != err {
+				return nil, fmt.Errorf("invalid compact notation: proposal #%d: %q is not an amount of judgments",
+					proposalIndex, grade)
+			}
+			rawTally = append(rawTally, amount)
+		}
+		rawTallies = append(rawTallies, rawTally)
+	}
+
+	return &parsedInput{Tally: pollTallyFromRaw(rawTallies)}, nil
+}
+
+func pollTallyFromRaw(rawTallies [][]uint64) *judgment.PollTally {
+	proposalsTallies := make([]*judgment.ProposalTally, 0, len(rawTallies))
+	for _, rawTally := range rawTallies {
+		proposalsTallies = append(proposalsTallies, &judgment.ProposalTally{Tally: rawTally})
+	}
+	return &judgment.PollTally{Proposals: proposalsTallies}
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	return nil == err
+}
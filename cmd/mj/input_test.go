@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGuessFormat(t *testing.T) {
+	assert.Equal(t, formatJSON, guessFormat("tally.json", []byte("2-2")))
+	assert.Equal(t, formatCSV, guessFormat("tally.CSV", []byte("")))
+	assert.Equal(t, formatCompact, guessFormat("tally.txt", []byte("{")))
+	assert.Equal(t, formatJSON, guessFormat("", []byte("  {\"proposals\": []}")))
+	assert.Equal(t, formatJSON, guessFormat("-", []byte("[[1, 2]]")))
+	assert.Equal(t, formatCSV, guessFormat("", []byte("1,2\n3,4")))
+	assert.Equal(t, formatCompact, guessFormat("", []byte("1-2_3-4")))
+}
+
+func TestParseJSON(t *testing.T) {
+	input, err := parseJSON([]byte(`{"amountOfJudges": 3, "proposals": [{"tally": [1, 2]}, {"tally": [0, 3]}]}`))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Equal(t, uint64(3), input.Tally.AmountOfJudges)
+	assert.Equal(t, []uint64{0, 3}, input.Tally.Proposals[1].Tally)
+
+	input, err = parseJSON([]byte(`[[1, 2], [0, 3]]`))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Equal(t, uint64(0), input.Tally.AmountOfJudges)
+	assert.Equal(t, []uint64{1, 2}, input.Tally.Proposals[0].Tally)
+
+	_, err = parseJSON([]byte(`{"proposals": [null]}`))
+	assert.Error(t, err, "Parsing should fail")
+	_, err = parseJSON([]byte(`[[1, -2]]`))
+	assert.Error(t, err, "Parsing should fail")
+}
+
+func TestParseCSV(t *testing.T) {
+	input, err := parseCSV([]byte("proposal,reject,fair,good\nPizza,1,2,3\n\"Fish, chips\",3,2,1\n"))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Equal(t, []string{"Pizza", "Fish, chips"}, input.ProposalNames)
+	assert.Equal(t, []string{"reject", "fair", "good"}, input.GradeNames)
+	assert.Equal(t, []uint64{3, 2, 1}, input.Tally.Proposals[1].Tally)
+
+	input, err = parseCSV([]byte("reject,fair,good\n1,2,3\n"))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Nil(t, input.ProposalNames)
+	assert.Equal(t, []string{"reject", "fair", "good"}, input.GradeNames)
+
+	input, err = parseCSV([]byte("1, 2, 3\n3, 2, 1\n"))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Nil(t, input.GradeNames)
+	assert.Len(t, input.Tally.Proposals, 2)
+
+	_, err = parseCSV([]byte("1,2,3\n3,two,1\n"))
+	assert.Error(t, err, "Parsing should fail")
+}
+
+func TestParseCompact(t *testing.T) {
+	input, err := parseCompact([]byte("2-2-2-2-2_2-1-1-1-5\n2-1-1-2-4\n"))
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Len(t, input.Tally.Proposals, 3)
+	assert.Equal(t, []uint64{2, 1, 1, 2, 4}, input.Tally.Proposals[2].Tally)
+
+	_, err = parseCompact([]byte("2-2_2-x"))
+	assert.Error(t, err, "Parsing should fail")
+}
@@ -0,0 +1,217 @@
+// Command mj deliberates a poll tally using Majority Judgment.
+//
+// It reads a PollTally as JSON, CSV or compact notation, from a file or stdin,
+// balances it if asked to, and prints the results as a table, JSON or Markdown.
+//
+//	mj -balance static -default-grade 0 tally.csv
+//	echo "2-2-2-2-2_2-1-1-1-5_2-1-1-2-4" | mj -output markdown
+//
+// It can also seal the poll into a reproducible bundle, and verify such bundles:
+//
+//	mj -output bundle -grades "bad,fair,good" tally.csv > bundle.json
+//	mj verify bundle.json
+//
+// Exit codes tell validation errors apart, see the exit* constants below.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Exit codes of the mj command
+const (
+	exitOK               = 0
+	exitFailure          = 1 // unexpected failure, like a broken stdout
+	exitUsage            = 2 // bad flags or arguments
+	exitInvalidInput     = 3 // the input could not be read or parsed
+	exitMishapedTally    = 4 // judgment.ErrMishapedTally
+	exitIncoherentTally  = 5 // judgment.ErrIncoherentTally
+	exitUnbalancedTally  = 6 // judgment.ErrUnbalancedTally
+	exitTooManyJudgments = 7 // judgment.ErrTooManyJudgments
+	exitBalancingFailure = 8 // the balancing strategy could not be applied
+	exitMismatch         = 9 // mj verify found mismatches in the bundle
+)
+
+// Output formats
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputMarkdown = "markdown"
+	outputBundle   = "bundle"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run is main() without the globals, so that we can test it.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 && "verify" == args[0] {
+		return runVerify(args[1:], stdin, stdout, stderr)
+	}
+
+	flags := flag.NewFlagSet("mj", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", formatAuto, "input format: auto, json, csv or compact")
+	balance := flags.String("balance", judgment.BalancingNone, "balancing strategy: none, static or median")
+	defaultGrade := flags.Uint("default-grade", 0, "default grade of the static balancing strategy, 0 being the worst")
+	amountOfJudges := flags.Uint64("judges", 0, "amount of judges ; guessed from the tally when 0")
+	output := flags.String("output", outputTable, "output format: table, json, markdown or bundle")
+	names := flags.String("names", "", "comma-separated names of the proposals")
+	grades := flags.String("grades", "", "comma-separated names of the grades, from worst to best")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mj [flags] [file]\n       mj verify [flags] [bundle]\n\n")
+		fmt.Fprintf(stderr, "Deliberates a poll tally read from file, or from stdin when file is - or missing.\n\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(stderr, "\nExit codes: %d ok, %d failure, %d usage, %d invalid input, %d mishaped tally, "+
+			"%d incoherent tally, %d unbalanced tally, %d too many judgments, %d balancing failure, %d mismatch\n",
+			exitOK, exitFailure, exitUsage, exitInvalidInput, exitMishapedTally,
+			exitIncoherentTally, exitUnbalancedTally, exitTooManyJudgments, exitBalancingFailure, exitMismatch)
+	}
+	if err := flags.Parse(args); nil != err {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	if *defaultGrade > 255 {
+		fmt.Fprintf(stderr, "mj: -default-grade must be lower than 256\n")
+		return exitUsage
+	}
+	if !isOneOf(*format, formatAuto, formatJSON, formatCSV, formatCompact) {
+		fmt.Fprintf(stderr, "mj: unknown input format %q\n", *format)
+		return exitUsage
+	}
+	if !isOneOf(*balance, judgment.BalancingNone, judgment.BalancingStatic, judgment.BalancingMedian) {
+		fmt.Fprintf(stderr, "mj: unknown balancing strategy %q\n", *balance)
+		return exitUsage
+	}
+	if !isOneOf(*output, outputTable, outputJSON, outputMarkdown, outputBundle) {
+		fmt.Fprintf(stderr, "mj: unknown output format %q\n", *output)
+		return exitUsage
+	}
+
+	path := flags.Arg(0)
+	content, err := readInput(path, stdin)
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitInvalidInput
+	}
+	if "" == strings.TrimSpace(string(content)) {
+		fmt.Fprintf(stderr, "mj: no tally was read, the input is empty\n")
+		return exitInvalidInput
+	}
+
+	if formatAuto == *format {
+		*format = guessFormat(path, content)
+	}
+	input, err := parseInput(*format, content)
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitInvalidInput
+	}
+	if 0 == len(input.Tally.Proposals) {
+		fmt.Fprintf(stderr, "mj: no tally was read, the input has no proposals\n")
+		return exitInvalidInput
+	}
+
+	pollTally := input.Tally
+	if 0 != *amountOfJudges {
+		pollTally.AmountOfJudges = *amountOfJudges
+	}
+	unbalancedTally := pollTally.Copy()
+	balancing := &judgment.BalancingStrategy{Kind: *balance, DefaultGrade: uint8(*defaultGrade)}
+	if err := pollTally.Balance(balancing); nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitBalancingFailure
+	}
+
+	deliberator := &judgment.MajorityJudgment{}
+	result, err := deliberator.Deliberate(pollTally)
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitCodeOf(err)
+	}
+
+	options := &judgment.ReportOptions{
+		ProposalNames: input.ProposalNames,
+		GradeNames:    input.GradeNames,
+		Balancing:     balancing.String(),
+	}
+	if "" != *names {
+		options.ProposalNames = splitNames(*names)
+	}
+	if "" != *grades {
+		options.GradeNames = splitNames(*grades)
+	}
+
+	switch *output {
+	case outputTable:
+		err = judgment.WriteTextTable(stdout, result, options)
+	case outputMarkdown:
+		err = judgment.WriteMarkdownTable(stdout, result, options)
+	case outputJSON:
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	case outputBundle:
+		err = writeBundle(stdout, unbalancedTally, balancing, options)
+	}
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func readInput(path string, stdin io.Reader) ([]byte, error) {
+	if "" == path || "-" == path {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// exitCodeOf maps the deliberation errors to our exit codes.
+func exitCodeOf(err error) int {
+	switch {
+	case errors.Is(err, judgment.ErrMishapedTally):
+		return exitMishapedTally
+	case errors.Is(err, judgment.ErrIncoherentTally):
+		return exitIncoherentTally
+	case errors.Is(err, judgment.ErrUnbalancedTally):
+		return exitUnbalancedTally
+	case errors.Is(err, judgment.ErrTooManyJudgments):
+		return exitTooManyJudgments
+	}
+	return exitFailure
+}
+
+func isOneOf(value string, allowed ...string) bool {
+	for _, candidate := range allowed {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func splitNames(s string) []string {
+	names := strings.Split(s, ",")
+	for nameIndex := range names {
+		names[nameIndex] = strings.TrimSpace(names[nameIndex])
+	}
+	return names
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runWithInput(t *testing.T, input string, args ...string) (int, string, string) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	code := run(args, strings.NewReader(input), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Table(t *testing.T) {
+	code, stdout, stderr := runWithInput(t, "2-2-2-2-2_2-1-1-1-5_2-1-1-2-4_2-1-5-0-2_2-2-2-2-2")
+	assert.Equal(t, exitOK, code, stderr)
+	lines := strings.Split(stdout, "\n")
+	assert.Contains(t, lines[2], "Proposal B")
+	assert.Contains(t, lines[5], "4=")
+}
+
+func TestRun_JSON(t *testing.T) {
+	code, stdout, stderr := runWithInput(t, "[[2, 2, 2, 2, 2], [2, 1, 1, 1, 5]]", "-output", "json")
+	assert.Equal(t, exitOK, code, stderr)
+	result := &judgment.PollResult{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), result))
+	assert.Equal(t, 1, result.Proposals[1].Rank)
+	assert.Equal(t, 2, result.Proposals[0].Rank)
+}
+
+func TestRun_MarkdownWithNames(t *testing.T) {
+	code, stdout, stderr := runWithInput(t, "1-2-3_3-2-1",
+		"-output", "markdown", "-names", "Pizza, Sushi", "-grades", "bad,fair,good")
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, "| Pizza ")
+	assert.Contains(t, stdout, "| fair ")
+}
+
+func TestRun_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tally.csv")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("name,bad,good\nPizza,1,2\nSushi,2,0\n"), 0644))
+	code, stdout, stderr := runWithInput(t, "", "-balance", "static", path)
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, stdout, "Pizza")
+}
+
+func TestRun_Balancing(t *testing.T) {
+	code, _, _ := runWithInput(t, "1-2-3_1-1-1")
+	assert.Equal(t, exitUnbalancedTally, code)
+	code, _, stderr := runWithInput(t, "1-2-3_1-1-1", "-balance", "median")
+	assert.Equal(t, exitOK, code, stderr)
+	code, _, stderr = runWithInput(t, "1-2-3_1-1-1", "-balance", "static", "-default-grade", "2")
+	assert.Equal(t, exitOK, code, stderr)
+	code, _, _ = runWithInput(t, "1-2-3_1-1-1", "-balance", "static", "-default-grade", "7")
+	assert.Equal(t, exitBalancingFailure, code)
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	code, _, _ := runWithInput(t, "1-2-3_1-1", "-balance", "none")
+	assert.Equal(t, exitMishapedTally, code)
+	code, _, _ = runWithInput(t, "1-2-3_1-1-1", "-judges", "2")
+	assert.Equal(t, exitIncoherentTally, code)
+	code, _, _ = runWithInput(t, "1-2-x")
+	assert.Equal(t, exitInvalidInput, code)
+	code, _, _ = runWithInput(t, "", "/this/file/does/not/exist.json")
+	assert.Equal(t, exitInvalidInput, code)
+	code, _, stderr := runWithInput(t, " \n")
+	assert.Equal(t, exitInvalidInput, code, "Empty input should not go unnoticed")
+	assert.Contains(t, stderr, "empty")
+	for _, input := range []string{"[]", `{"proposals":[]}`, "name,bad,good\n"} {
+		code, _, stderr = runWithInput(t, input)
+		assert.Equal(t, exitInvalidInput, code, "Inputs without proposals should not go unnoticed: %s", input)
+		assert.Contains(t, stderr, "no proposals", input)
+	}
+	code, _, _ = runWithInput(t, "1-2", "-output", "pdf")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runWithInput(t, "1-2", "-balance", "mean")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runWithInput(t, "1-2", "-nope")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runWithInput(t, "1-2", "a.json", "b.json")
+	assert.Equal(t, exitUsage, code)
+}
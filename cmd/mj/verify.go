@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/bundle"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io"
+)
+
+// runVerify is the verify subcommand: it recomputes the result of a bundle, and reports the mismatches.
+func runVerify(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mj verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print the verification as JSON")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mj verify [flags] [bundle]\n\n")
+		fmt.Fprintf(stderr, "Recomputes the result of a bundle read from file, or from stdin when file is - or missing,\n")
+		fmt.Fprintf(stderr, "and reports any mismatch field by field.  Exits with %d when there are mismatches.\n\n", exitMismatch)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); nil != err {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	content, err := readInput(flags.Arg(0), stdin)
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitInvalidInput
+	}
+	b := &bundle.Bundle{}
+	if err := json.Unmarshal(content, b); nil != err {
+		fmt.Fprintf(stderr, "mj: invalid bundle: %v\n", err)
+		return exitInvalidInput
+	}
+	verification, err := b.Verify()
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: cannot verify the bundle: %v\n", err)
+		return exitInvalidInput
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(verification)
+	} else {
+		err = writeVerification(stdout, verification)
+	}
+	if nil != err {
+		fmt.Fprintf(stderr, "mj: %v\n", err)
+		return exitFailure
+	}
+
+	if !verification.OK() {
+		return exitMismatch
+	}
+	return exitOK
+}
+
+func writeVerification(w io.Writer, verification *bundle.Verification) error {
+	for _, warning := range verification.Warnings {
+		if _, err := fmt.Fprintf(w, "warning: %s\n", warning); nil != err {
+			return err
+		}
+	}
+	for _, mismatch := range verification.Mismatches {
+		if _, err := fmt.Fprintf(w, "mismatch: %s\n", mismatch); nil != err {
+			return err
+		}
+	}
+	if verification.OK() {
+		_, err := fmt.Fprintln(w, "ok: the result matches the bundle")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d mismatch(es)\n", len(verification.Mismatches))
+	return err
+}
+
+// writeBundle seals the tally, before balancing, into a bundle.
+func writeBundle(w io.Writer, pollTally *judgment.PollTally, balancing *judgment.BalancingStrategy, options *judgment.ReportOptions) error {
+	b := &bundle.Bundle{
+		Tally:     pollTally,
+		Balancing: balancing,
+		Proposals: options.ProposalNames,
+	}
+	if 0 != len(pollTally.Proposals) {
+		for grade := range pollTally.Proposals[0].Tally {
+			b.Grades = append(b.Grades, options.GradeName(uint8(grade)))
+		}
+	}
+	if err := b.Seal(); nil != err {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
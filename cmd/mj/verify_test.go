@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/bundle"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRun_BundleAndVerify(t *testing.T) {
+	code, sealed, stderr := runWithInput(t, "1-2-3_3-2-0",
+		"-output", "bundle", "-balance", "static", "-names", "Pizza,Sushi", "-grades", "bad,fair,good")
+	assert.Equal(t, exitOK, code, stderr)
+	b := &bundle.Bundle{}
+	assert.NoError(t, json.Unmarshal([]byte(sealed), b), "Output should be a bundle")
+	assert.Equal(t, []string{"bad", "fair", "good"}, b.Grades)
+	assert.Equal(t, []uint64{3, 2, 0}, b.Tally.Proposals[1].Tally, "The bundle should hold the tally before balancing")
+
+	code, stdout, stderr := runWithInput(t, sealed, "verify")
+	assert.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "ok: the result matches the bundle\n", stdout)
+
+	tampered := strings.Replace(sealed, `"rank": 2`, `"rank": 1`, 1)
+	code, stdout, _ = runWithInput(t, tampered, "verify")
+	assert.Equal(t, exitMismatch, code)
+	assert.Contains(t, stdout, "mismatch: result.proposals[1].rank: recorded 1, recomputed 2\n")
+	assert.Contains(t, stdout, "mismatch: hashes.result: ")
+
+	code, stdout, _ = runWithInput(t, tampered, "verify", "-json")
+	assert.Equal(t, exitMismatch, code)
+	verification := &bundle.Verification{}
+	assert.NoError(t, json.Unmarshal([]byte(stdout), verification))
+	assert.False(t, verification.OK())
+}
+
+func TestRunVerify_Failures(t *testing.T) {
+	code, _, _ := runWithInput(t, "{", "verify")
+	assert.Equal(t, exitInvalidInput, code)
+	code, _, _ = runWithInput(t, `{"format": 42}`, "verify")
+	assert.Equal(t, exitInvalidInput, code)
+	code, _, _ = runWithInput(t, "", "verify", "/this/file/does/not/exist.json")
+	assert.Equal(t, exitInvalidInput, code)
+	code, _, _ = runWithInput(t, "", "verify", "a.json", "b.json")
+	assert.Equal(t, exitUsage, code)
+	code, _, _ = runWithInput(t, "", "verify", "-nope")
+	assert.Equal(t, exitUsage, code)
+}
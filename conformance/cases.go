@@ -0,0 +1,171 @@
+// Package conformance holds canonical Majority Judgment cases, and checks deliberators against them.
+//
+// The cases are also published as cases.json, next to this file, so that implementations in other languages
+// share the same truth.  Run `go test ./conformance -update` to regenerate it after changing the cases.
+//
+//	failures := conformance.Check(&judgment.MajorityJudgment{}, conformance.Cases(), &conformance.Options{CheckScores: true})
+package conformance
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"math"
+)
+
+// Case is a poll and the expected outcome of its deliberation.
+type Case struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Tally       *judgment.PollTally         `json:"tally"`
+	Balancing   *judgment.BalancingStrategy `json:"balancing,omitempty"` // applied to the tally before deliberating
+	Ranks       []int                       `json:"ranks"`               // expected rank of each proposal, in the order of the tally
+	// Scores are specific to the score-based algorithm of this library ; other implementations may skip them.
+	Scores []string `json:"scores,omitempty"`
+	// Error is the expected error instead of a result, as the message of one of the errors of the judgment package.
+	Error string `json:"error,omitempty"`
+}
+
+// Cases returns the canonical cases.  Each call returns fresh tallies, so that deliberators may mutate them.
+func Cases() []Case {
+	return []Case{
+		{
+			Name:        "basic",
+			Description: "Three proposals, three judges, three grades.",
+			Tally:       tally(3, []uint64{1, 1, 1}, []uint64{1, 2, 0}, []uint64{0, 2, 1}),
+			Ranks:       []int{2, 3, 1},
+			Scores:      []string{"120423", "120303", "142303"},
+		},
+		{
+			Name:        "billions of judges",
+			Description: "Twenty billion judges, decided by a single judgment.",
+			Tally:       tally(20e9, []uint64{10e9, 10e9}, []uint64{9999999999, 10000000001}),
+			Ranks:       []int{2, 1},
+			Scores:      []string{"030000000000120000000000", "110000000001020000000000"},
+		},
+		{
+			Name:        "readme demo",
+			Description: "The example of the README, where the first and last proposals are tied.",
+			Tally: tally(10,
+				[]uint64{2, 2, 2, 2, 2},
+				[]uint64{2, 1, 1, 1, 5},
+				[]uint64{2, 1, 1, 2, 4},
+				[]uint64{2, 1, 5, 0, 2},
+				[]uint64{2, 2, 2, 2, 2},
+			),
+			Ranks:  []int{4, 1, 2, 3, 4},
+			Scores: []string{"206114308012410", "315406207108010", "306214407108010", "207108012410010", "206114308012410"},
+		},
+		{
+			Name:        "guessed amount of judges",
+			Description: "Same as the readme demo, but the amount of judges is left for the deliberator to guess.",
+			Tally: tally(0,
+				[]uint64{2, 2, 2, 2, 2},
+				[]uint64{2, 1, 1, 1, 5},
+				[]uint64{2, 1, 1, 2, 4},
+				[]uint64{2, 1, 5, 0, 2},
+				[]uint64{2, 2, 2, 2, 2},
+			),
+			Ranks:  []int{4, 1, 2, 3, 4},
+			Scores: []string{"206114308012410", "315406207108010", "306214407108010", "207108012410010", "206114308012410"},
+		},
+		{
+			Name:        "no proposals",
+			Description: "An empty poll yields an empty result.",
+			Tally:       tally(0),
+			Ranks:       []int{},
+		},
+		{
+			Name:        "single proposal",
+			Description: "A lone proposal always wins.",
+			Tally:       tally(0, []uint64{1, 2, 3}),
+			Ranks:       []int{1},
+			Scores:      []string{"109205006"},
+		},
+		{
+			Name:        "no judgments",
+			Description: "Proposals without any judgment are tied.",
+			Tally:       tally(0, []uint64{0, 0, 0}, []uint64{0, 0, 0}),
+			Ranks:       []int{1, 1},
+			Scores:      []string{"000000", "000000"},
+		},
+		{
+			Name:        "single grade",
+			Description: "With a single grade, all proposals are tied.",
+			Tally:       tally(0, []uint64{3}, []uint64{3}),
+			Ranks:       []int{1, 1},
+			Scores:      []string{"03", "03"},
+		},
+		{
+			Name:        "low median",
+			Description: "With an even amount of judges, the majority grade is the lower of both middle grades.",
+			Tally:       tally(0, []uint64{0, 2, 2, 0}, []uint64{0, 1, 3, 0}, []uint64{1, 1, 1, 1}),
+			Ranks:       []int{2, 1, 3},
+			Scores:      []string{"16240404", "23140404", "16230534"},
+		},
+		{
+			Name:        "decided after removing the median judgments",
+			Description: "Both proposals share their majority grade and their groups, and separate at the next step.",
+			Tally:       tally(0, []uint64{1, 0, 1, 1}, []uint64{0, 1, 1, 1}),
+			Ranks:       []int{2, 1},
+			Scores:      []string{"22043303", "22143303"},
+		},
+		{
+			Name:        "adhesion versus contestation",
+			Description: "With the same majority grade, the biggest group decides, and equal groups count as contestation.",
+			Tally:       tally(0, []uint64{1, 2, 1}, []uint64{0, 3, 1}, []uint64{1, 3, 0}),
+			Ranks:       []int{2, 1, 3},
+			Scores:      []string{"130524", "152404", "130404"},
+		},
+		{
+			Name:        "static default grade",
+			Description: "Missing judgments count as the worst grade.",
+			Tally:       tally(10, []uint64{2, 1, 2, 2, 1}, []uint64{3, 1, 3, 1, 1}, []uint64{0, 1, 1, 0, 0}),
+			Balancing:   &judgment.BalancingStrategy{Kind: judgment.BalancingStatic, DefaultGrade: 0},
+			Ranks:       []int{1, 2, 3},
+			Scores:      []string{"115206013311410", "115206012311410", "012111210010010"},
+		},
+		{
+			Name:        "median default grade",
+			Description: "Missing judgments count as the majority grade of the proposal.",
+			Tally:       tally(10, []uint64{2, 1, 2, 2, 1}, []uint64{3, 1, 3, 1, 1}, []uint64{0, 1, 1, 0, 0}),
+			Balancing:   &judgment.BalancingStrategy{Kind: judgment.BalancingMedian},
+			Ranks:       []int{1, 2, 3},
+			Scores:      []string{"207113308011410", "206107012311410", "111210010010010"},
+		},
+		{
+			Name:        "incoherent tally",
+			Description: "Proposals hold more judgments than there are judges.",
+			Tally:       tally(2, []uint64{4, 4}, []uint64{2, 6}),
+			Error:       judgment.ErrIncoherentTally.Error(),
+		},
+		{
+			Name:        "mishaped tally",
+			Description: "Proposals hold different amounts of grades.",
+			Tally:       tally(10, []uint64{2, 2, 2, 2, 2}, []uint64{2, 2, 2, 2}),
+			Error:       judgment.ErrMishapedTally.Error(),
+		},
+		{
+			Name:        "unbalanced tally",
+			Description: "A proposal holds less judgments than there are judges, and no balancing is applied.",
+			Tally:       tally(10, []uint64{2, 2, 2, 2, 2}, []uint64{2, 0, 0, 0, 2}),
+			Error:       judgment.ErrUnbalancedTally.Error(),
+		},
+		{
+			Name:        "too many judgments",
+			Description: "More judgments than a signed 64-bit integer holds ; implementations with big integers may deliberate instead.",
+			Tally:       tally(math.MaxInt64+1, []uint64{math.MaxInt64, 1}, []uint64{math.MaxInt64, 1}),
+			Error:       judgment.ErrTooManyJudgments.Error(),
+		},
+	}
+}
+
+// tally makes a PollTally out of raw tallies.
+func tally(amountOfJudges uint64, tallies ...[]uint64) *judgment.PollTally {
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: amountOfJudges,
+		Proposals:      make([]*judgment.ProposalTally, 0, len(tallies)),
+	}
+	for _, proposalTally := range tallies {
+		pollTally.Proposals = append(pollTally.Proposals, &judgment.ProposalTally{Tally: proposalTally})
+	}
+	return pollTally
+}
@@ -0,0 +1,43 @@
+package conformance
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate cases.json from the cases")
+
+func TestCases_Fixture(t *testing.T) {
+	data, err := json.MarshalIndent(Cases(), "", "  ")
+	assert.NoError(t, err)
+	data = append(data, '\n')
+	if *update {
+		assert.NoError(t, ioutil.WriteFile("cases.json", data, 0644))
+	}
+	fixture, err := ioutil.ReadFile("cases.json")
+	assert.NoError(t, err, "The fixture should exist")
+	assert.Equal(t, string(data), string(fixture), "The fixture should match the cases ; run go test -update")
+
+	cases := []Case{}
+	assert.NoError(t, json.Unmarshal(fixture, &cases), "The fixture should be readable")
+	assert.Equal(t, Cases(), cases)
+}
+
+func TestCases_Fresh(t *testing.T) {
+	cases := Cases()
+	cases[0].Tally.Proposals[0].Tally[0] = 42
+	assert.Equal(t, uint64(1), Cases()[0].Tally.Proposals[0].Tally[0], "Cases should not share their tallies")
+	for _, c := range Cases() {
+		assert.NotEmpty(t, c.Name)
+		assert.NotEmpty(t, c.Description)
+		if "" == c.Error {
+			assert.Len(t, c.Ranks, len(c.Tally.Proposals), c.Name)
+			assert.Len(t, c.Scores, len(c.Tally.Proposals), c.Name)
+		}
+	}
+	assert.Equal(t, judgment.ErrMishapedTally.Error(), "mishaped tally")
+}
@@ -0,0 +1,100 @@
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+)
+
+// Options of Check
+type Options struct {
+	CheckScores bool // only for deliberators sharing the score-based algorithm of this library
+}
+
+// Failure is a case a deliberator did not pass.
+type Failure struct {
+	Case    string
+	Message string
+}
+
+// String describes the failure for humans.
+func (failure Failure) String() string {
+	return fmt.Sprintf("%s: %s", failure.Case, failure.Message)
+}
+
+// knownErrors are the errors cases may expect, by message.
+var knownErrors = []error{
+	judgment.ErrMishapedTally,
+	judgment.ErrIncoherentTally,
+	judgment.ErrUnbalancedTally,
+	judgment.ErrTooManyJudgments,
+}
+
+// Check deliberates each case, and returns the failures ; none means the deliberator conforms.
+// Expected errors are matched with errors.Is when the deliberator returns errors of the judgment package,
+// any error being accepted otherwise.  options may be nil.
+func Check(deliberator judgment.DeliberatorInterface, cases []Case, options *Options) (failures []Failure) {
+	for _, c := range cases {
+		for _, message := range checkCase(deliberator, c, options) {
+			failures = append(failures, Failure{Case: c.Name, Message: message})
+		}
+	}
+	return failures
+}
+
+// checkCase returns what went wrong with a case.
+func checkCase(deliberator judgment.DeliberatorInterface, c Case, options *Options) (messages []string) {
+	pollTally := c.Tally.Copy()
+	if err := pollTally.Balance(c.Balancing); nil != err {
+		return []string{fmt.Sprintf("balancing failed: %v", err)}
+	}
+	result, err := deliberator.Deliberate(pollTally)
+
+	if "" != c.Error {
+		if nil == err {
+			return []string{fmt.Sprintf("expected error %q, got a result", c.Error)}
+		}
+		for _, known := range knownErrors {
+			if errors.Is(err, known) && known.Error() != c.Error {
+				return []string{fmt.Sprintf("expected error %q, got %q", c.Error, err)}
+			}
+		}
+		return nil
+	}
+	if nil != err {
+		return []string{fmt.Sprintf("unexpected error: %v", err)}
+	}
+	if nil == result || len(result.Proposals) != len(c.Ranks) {
+		return []string{fmt.Sprintf("expected %d proposals in the result", len(c.Ranks))}
+	}
+
+	for proposalIndex, proposalResult := range result.Proposals {
+		if proposalIndex != proposalResult.Index {
+			messages = append(messages, fmt.Sprintf("proposal #%d has index %d", proposalIndex, proposalResult.Index))
+		}
+		if c.Ranks[proposalIndex] != proposalResult.Rank {
+			messages = append(messages, fmt.Sprintf("proposal #%d: expected rank %d, got %d",
+				proposalIndex, c.Ranks[proposalIndex], proposalResult.Rank))
+		}
+		if nil != options && options.CheckScores && proposalIndex < len(c.Scores) && c.Scores[proposalIndex] != proposalResult.Score {
+			messages = append(messages, fmt.Sprintf("proposal #%d: expected score %s, got %s",
+				proposalIndex, c.Scores[proposalIndex], proposalResult.Score))
+		}
+	}
+
+	if len(result.ProposalsSorted) != len(result.Proposals) {
+		return append(messages, "sorted proposals should hold all the proposals")
+	}
+	seen := make(map[int]bool, len(result.ProposalsSorted))
+	for sortedIndex, proposalResult := range result.ProposalsSorted {
+		if seen[proposalResult.Index] {
+			messages = append(messages, fmt.Sprintf("proposal #%d is sorted twice", proposalResult.Index))
+		}
+		seen[proposalResult.Index] = true
+		if sortedIndex > 0 && proposalResult.Rank < result.ProposalsSorted[sortedIndex-1].Rank {
+			messages = append(messages, "sorted proposals should be sorted by rank")
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,69 @@
+package conformance
+
+import (
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheck_MajorityJudgment(t *testing.T) {
+	failures := Check(&judgment.MajorityJudgment{}, Cases(), &Options{CheckScores: true})
+	assert.Empty(t, failures, "Our own deliberator should conform")
+}
+
+// reversedDeliberator ranks proposals the other way around.
+type reversedDeliberator struct{}
+
+func (deliberator *reversedDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	result, err := (&judgment.MajorityJudgment{}).Deliberate(tally)
+	if nil != err {
+		return nil, err
+	}
+	for _, proposalResult := range result.Proposals {
+		proposalResult.Rank = len(result.Proposals) + 1 - proposalResult.Rank
+	}
+	return result, nil
+}
+
+// lenientDeliberator never fails.
+type lenientDeliberator struct{}
+
+func (deliberator *lenientDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	return &judgment.PollResult{}, nil
+}
+
+// foreignDeliberator fails with its own errors.
+type foreignDeliberator struct{}
+
+func (deliberator *foreignDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	return nil, errors.New("nope")
+}
+
+func TestCheck_Failures(t *testing.T) {
+	failures := Check(&reversedDeliberator{}, Cases(), nil)
+	assert.NotEmpty(t, failures)
+	assert.Equal(t, "basic: proposal #1: expected rank 3, got 1", failures[0].String())
+	assert.Contains(t, failures, Failure{Case: "basic", Message: "proposal #2: expected rank 1, got 3"})
+	assert.Contains(t, failures, Failure{Case: "basic", Message: "sorted proposals should be sorted by rank"})
+
+	failures = Check(&lenientDeliberator{}, Cases(), nil)
+	assert.Contains(t, failures, Failure{Case: "incoherent tally", Message: `expected error "incoherent tally", got a result`})
+	assert.Contains(t, failures, Failure{Case: "basic", Message: "expected 3 proposals in the result"})
+	assert.NotContains(t, failures, Failure{Case: "no proposals", Message: "expected 0 proposals in the result"})
+
+	failures = Check(&foreignDeliberator{}, Cases(), nil)
+	for _, failure := range failures {
+		assert.Contains(t, failure.Message, "unexpected error", "Foreign errors should be accepted when errors are expected")
+	}
+	assert.Contains(t, failures, Failure{Case: "basic", Message: "unexpected error: nope"})
+
+	failures = Check(&judgment.MajorityJudgment{}, []Case{{
+		Name:  "wrong error",
+		Tally: tally(2, []uint64{4, 4}, []uint64{2, 6}),
+		Error: judgment.ErrMishapedTally.Error(),
+	}}, nil)
+	assert.Equal(t, []Failure{{Case: "wrong error", Message: `expected error "mishaped tally", got "incoherent tally: ` +
+		`some proposals hold more judgments than the specified amount of judges ; ` +
+		`perhaps you forgot to set PollTally.AmountOfJudges or to call PollTally.GuessAmountOfJudges()"`}}, failures)
+}
@@ -0,0 +1,312 @@
+package judgment
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+)
+
+// AnimationOptions tune the animated GIF of the evolution of a poll.
+// Zero values are replaced by sensible defaults.
+type AnimationOptions struct {
+	Width       int           // Width of the image, in pixels.  Defaults to 480.
+	RowHeight   int           // Height of each proposal's row, in pixels.  Defaults to 24.
+	TweenFrames int           // Amount of interpolated frames between two snapshots.  Defaults to 8.
+	TweenDelay  int           // Delay of each interpolated frame, in 100ths of a second.  Defaults to 4.
+	HoldDelay   int           // Delay of each snapshot's frame, in 100ths of a second.  Defaults to 100.
+	Palette     color.Palette // One color per grade ; defaults to CreateDefaultPalette.
+}
+
+// Indices of the fixed colors of the GIF palette ; the grades' colors come after them.
+const (
+	animationBackground uint8 = iota
+	animationInk
+	animationRule
+	animationRise
+	animationFall
+	animationFixedColors
+)
+
+// RenderEvolutionGIF writes an animated GIF replaying how the ranking and merit profiles
+// evolved along the provided snapshots of a poll, which must all hold the same proposals and grades.
+// Each snapshot is deliberated using MajorityJudgment, so it has to be balanced.
+// Rows slide towards their new rank and bar lengths are interpolated between snapshots,
+// and rank changes since the previous snapshot are annotated with a colored arrow.
+// options may be nil.
+func RenderEvolutionGIF(w io.Writer, snapshots []*PollTally, options *AnimationOptions) error {
+	if 0 == len(snapshots) {
+		return fmt.Errorf("RenderEvolutionGIF() no snapshots were provided")
+	}
+	options = options.withDefaults()
+
+	amountOfProposals := len(snapshots[0].Proposals)
+	amountOfGrades := 0
+	if amountOfProposals > 0 {
+		amountOfGrades = len(snapshots[0].Proposals[0].Tally)
+	}
+	if amountOfGrades+int(animationFixedColors) > 256 {
+		return fmt.Errorf("RenderEvolutionGIF() too many grades to fit in a GIF palette")
+	}
+
+	results := make([]*PollResult, 0, len(snapshots))
+	deliberator := &MajorityJudgment{}
+	for snapshotIndex, snapshot := range snapshots {
+		if amountOfProposals != len(snapshot.Proposals) {
+			return fmt.Errorf("RenderEvolutionGIF() snapshot #%d holds %d proposals instead of %d",
+				snapshotIndex, len(snapshot.Proposals), amountOfProposals)
+		}
+		result, err := deliberator.Deliberate(snapshot)
+		if nil != err {
+			return fmt.Errorf("RenderEvolutionGIF() snapshot #%d: %v", snapshotIndex, err)
+		}
+		if 0 < amountOfProposals && amountOfGrades != result.countGrades() {
+			return fmt.Errorf("RenderEvolutionGIF() snapshot #%d holds %d grades instead of %d",
+				snapshotIndex, result.countGrades(), amountOfGrades)
+		}
+		results = append(results, result)
+	}
+
+	palette := animationPalette(options.Palette, amountOfGrades)
+
+	animation := &gif.GIF{}
+	for resultIndex, result := range results {
+		previous := result
+		if resultIndex > 0 {
+			previous = results[resultIndex-1]
+		}
+		next := result
+		if resultIndex+1 < len(results) {
+			next = results[resultIndex+1]
+		}
+
+		animation.Image = append(animation.Image, renderEvolutionFrame(options, palette, result, result, previous, 0))
+		animation.Delay = append(animation.Delay, options.HoldDelay)
+		if next == result {
+			continue
+		}
+		for tween := 1; tween <= options.TweenFrames; tween++ {
+			progress := float64(tween) / float64(options.TweenFrames+1)
+			animation.Image = append(animation.Image, renderEvolutionFrame(options, palette, result, next, previous, progress))
+			animation.Delay = append(animation.Delay, options.TweenDelay)
+		}
+	}
+
+	return gif.EncodeAll(w, animation)
+}
+
+// animationPalette returns the quantized palette of the GIF: a few fixed colors, then one color per grade.
+func animationPalette(gradesPalette color.Palette, amountOfGrades int) color.Palette {
+	if len(gradesPalette) < amountOfGrades {
+		gradesPalette = CreateDefaultPalette(amountOfGrades)
+	}
+	palette := color.Palette{
+		animationBackground: color.White,
+		animationInk:        color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff},
+		animationRule:       color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff},
+		animationRise:       hexToRGB(0x00a249),
+		animationFall:       hexToRGB(0xdf3222),
+	}
+	for grade := 0; grade < amountOfGrades; grade++ {
+		palette = append(palette, gradesPalette[grade])
+	}
+	return palette
+}
+
+func (options *AnimationOptions) withDefaults() *AnimationOptions {
+	out := &AnimationOptions{}
+	if nil != options {
+		*out = *options
+	}
+	if out.Width <= 0 {
+		out.Width = 480
+	}
+	if out.RowHeight <= 0 {
+		out.RowHeight = 24
+	}
+	if out.TweenFrames < 0 {
+		out.TweenFrames = 0
+	} else if 0 == out.TweenFrames {
+		out.TweenFrames = 8
+	}
+	if out.TweenDelay <= 0 {
+		out.TweenDelay = 4
+	}
+	if out.HoldDelay <= 0 {
+		out.HoldDelay = 100
+	}
+	return out
+}
+
+// renderEvolutionFrame draws the state between from and to, progress going from 0 (from) to 1 (to).
+// Rank changes are annotated relative to the previous snapshot of from.
+func renderEvolutionFrame(
+	options *AnimationOptions,
+	palette color.Palette,
+	from *PollResult,
+	to *PollResult,
+	previous *PollResult,
+	progress float64,
+) *image.Paletted {
+	const labelsWidth = 56
+	const changesWidth = 40
+	const glyphScale = 2
+	margin := options.RowHeight / 2
+
+	amountOfProposals := len(from.Proposals)
+	height := 2*margin + amountOfProposals*options.RowHeight
+	frame := image.NewPaletted(image.Rect(0, 0, options.Width, height), palette)
+	barLeft := labelsWidth
+	barRight := options.Width - changesWidth
+	if barRight <= barLeft {
+		barRight = barLeft + 1
+	}
+	barHeight := options.RowHeight * 2 / 3
+
+	positionsFrom := sortedPositions(from)
+	positionsTo := sortedPositions(to)
+
+	for proposalIndex := 0; proposalIndex < amountOfProposals; proposalIndex++ {
+		position := lerp(float64(positionsFrom[proposalIndex]), float64(positionsTo[proposalIndex]), progress)
+		top := margin + int(position*float64(options.RowHeight)+0.5) + (options.RowHeight-barHeight)/2
+
+		// Rank and proposal letter, on the left
+		rank := from.Proposals[proposalIndex].Rank
+		if progress >= 0.5 {
+			rank = to.Proposals[proposalIndex].Rank
+		}
+		label := fmt.Sprintf("%d %s", rank, lettersFromIndex(proposalIndex))
+		drawGlyphs(frame, label, 4, top+(barHeight-5*glyphScale)/2, glyphScale, animationInk)
+
+		// Merit profile, interpolated grade by grade
+		tallyFrom := from.Proposals[proposalIndex].Tally
+		tallyTo := to.Proposals[proposalIndex].Tally
+		totalFrom := float64(tallyFrom.CountJudgments())
+		totalTo := float64(tallyTo.CountJudgments())
+		cursor := 0.0
+		for grade := range tallyFrom.Tally {
+			shareFrom, shareTo := 0.0, 0.0
+			if totalFrom > 0 {
+				shareFrom = float64(tallyFrom.Tally[grade]) / totalFrom
+			}
+			if totalTo > 0 {
+				shareTo = float64(tallyTo.Tally[grade]) / totalTo
+			}
+			share := lerp(shareFrom, shareTo, progress)
+			left := barLeft + int(cursor*float64(barRight-barLeft)+0.5)
+			cursor += share
+			right := barLeft + int(cursor*float64(barRight-barLeft)+0.5)
+			fillRect(frame, image.Rect(left, top, right, top+barHeight), animationFixedColors+uint8(grade))
+		}
+
+		// Rank change since the previous snapshot, on the right
+		rankChange := previous.Proposals[proposalIndex].Rank - from.Proposals[proposalIndex].Rank
+		arrowLeft := barRight + 4
+		arrowTop := top + (barHeight-5*glyphScale)/2
+		if rankChange > 0 {
+			drawArrow(frame, arrowLeft, arrowTop, glyphScale, true, animationRise)
+			drawGlyphs(frame, fmt.Sprintf("%d", rankChange), arrowLeft+4*glyphScale, arrowTop, glyphScale, animationRise)
+		} else if rankChange < 0 {
+			drawArrow(frame, arrowLeft, arrowTop, glyphScale, false, animationFall)
+			drawGlyphs(frame, fmt.Sprintf("%d", -rankChange), arrowLeft+4*glyphScale, arrowTop, glyphScale, animationFall)
+		}
+	}
+
+	// The majority line
+	middle := (barLeft + barRight) / 2
+	fillRect(frame, image.Rect(middle, margin/2, middle+1, height-margin/2), animationRule)
+
+	return frame
+}
+
+// sortedPositions returns, for each proposal in input order, its position in the sorted results.
+func sortedPositions(result *PollResult) []int {
+	positions := make([]int, len(result.Proposals))
+	for position, proposalResult := range result.ProposalsSorted {
+		positions[proposalResult.Index] = position
+	}
+	return positions
+}
+
+func lerp(from float64, to float64, progress float64) float64 {
+	return from + (to-from)*progress
+}
+
+func fillRect(frame *image.Paletted, rect image.Rectangle, colorIndex uint8) {
+	rect = rect.Intersect(frame.Rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			frame.SetColorIndex(x, y, colorIndex)
+		}
+	}
+}
+
+// drawArrow draws a small triangle pointing up or down, three glyph pixels wide and five high.
+func drawArrow(frame *image.Paletted, left int, top int, scale int, up bool, colorIndex uint8) {
+	rows := []string{".#.", "###", "###", ".#.", ".#."}
+	if !up {
+		rows = []string{".#.", ".#.", "###", "###", ".#."}
+	}
+	drawGlyphRows(frame, rows, left, top, scale, colorIndex)
+}
+
+// drawGlyphs writes text using a tiny 3×5 pixel font ; unknown characters are left blank.
+func drawGlyphs(frame *image.Paletted, text string, left int, top int, scale int, colorIndex uint8) {
+	for _, r := range text {
+		if rows, exists := tinyFont[r]; exists {
+			drawGlyphRows(frame, rows, left, top, scale, colorIndex)
+		}
+		left += 4 * scale
+	}
+}
+
+func drawGlyphRows(frame *image.Paletted, rows []string, left int, top int, scale int, colorIndex uint8) {
+	for y, row := range rows {
+		for x, pixel := range row {
+			if '#' != pixel {
+				continue
+			}
+			fillRect(frame, image.Rect(left+x*scale, top+y*scale, left+(x+1)*scale, top+(y+1)*scale), colorIndex)
+		}
+	}
+}
+
+var tinyFont = map[rune][]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", ".##", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'A': {".#.", "#.#", "###", "#.#", "#.#"},
+	'B': {"##.", "#.#", "##.", "#.#", "##."},
+	'C': {".##", "#..", "#..", "#..", ".##"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'F': {"###", "#..", "##.", "#..", "#.."},
+	'G': {".##", "#..", "#.#", "#.#", ".##"},
+	'H': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I': {"###", ".#.", ".#.", ".#.", "###"},
+	'J': {"..#", "..#", "..#", "#.#", ".#."},
+	'K': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L': {"#..", "#..", "#..", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {".#.", "#.#", "#.#", "#.#", ".#."},
+	'P': {"##.", "#.#", "##.", "#..", "#.."},
+	'Q': {".#.", "#.#", "#.#", "##.", ".##"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S': {".##", "#..", ".#.", "..#", "##."},
+	'T': {"###", ".#.", ".#.", ".#.", ".#."},
+	'U': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V': {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W': {"#.#", "#.#", "###", "###", "#.#"},
+	'X': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+}
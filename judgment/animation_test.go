@@ -0,0 +1,94 @@
+package judgment
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image/gif"
+	"testing"
+)
+
+func evolutionSnapshots() []*PollTally {
+	return []*PollTally{
+		{
+			AmountOfJudges: 4,
+			Proposals: []*ProposalTally{
+				{Tally: []uint64{0, 1, 1, 2}},
+				{Tally: []uint64{2, 1, 1, 0}},
+				{Tally: []uint64{1, 1, 1, 1}},
+			},
+		},
+		{
+			AmountOfJudges: 8,
+			Proposals: []*ProposalTally{
+				{Tally: []uint64{3, 2, 1, 2}},
+				{Tally: []uint64{2, 1, 1, 4}},
+				{Tally: []uint64{1, 3, 3, 1}},
+			},
+		},
+		{
+			AmountOfJudges: 12,
+			Proposals: []*ProposalTally{
+				{Tally: []uint64{3, 2, 4, 3}},
+				{Tally: []uint64{2, 1, 4, 5}},
+				{Tally: []uint64{5, 3, 3, 1}},
+			},
+		},
+	}
+}
+
+func TestRenderEvolutionGIF(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := RenderEvolutionGIF(buffer, evolutionSnapshots(), &AnimationOptions{TweenFrames: 3, HoldDelay: 50})
+	assert.NoError(t, err, "Rendering should succeed")
+
+	animation, err := gif.DecodeAll(buffer)
+	assert.NoError(t, err, "The GIF should be readable")
+	assert.Len(t, animation.Image, 3+2*3, "There should be one frame per snapshot plus the tweens")
+	assert.Equal(t, 50, animation.Delay[0])
+	assert.Equal(t, 4, animation.Delay[1])
+	assert.Equal(t, 480, animation.Config.Width)
+	// The GIF encoder pads the palette up to a power of two
+	assert.Len(t, animation.Image[0].Palette, 16, "The palette should hold the grades' colors")
+
+	gradeColor := animation.Image[0].Palette[animationFixedColors]
+	assert.Equal(t, "#df3222", DumpColorHexString(gradeColor, "#", false))
+}
+
+func TestRenderEvolutionGIF_AnnotatesRankChanges(t *testing.T) {
+	results := make([]*PollResult, 0, 2)
+	for _, snapshot := range evolutionSnapshots()[:2] {
+		result, err := (&MajorityJudgment{}).Deliberate(snapshot)
+		assert.NoError(t, err)
+		results = append(results, result)
+	}
+	options := (&AnimationOptions{}).withDefaults()
+	palette := animationPalette(nil, 4)
+
+	steady := renderEvolutionFrame(options, palette, results[0], results[0], results[0], 0)
+	changed := renderEvolutionFrame(options, palette, results[1], results[1], results[0], 0)
+	assert.False(t, containsColorIndex(steady.Pix, animationRise), "No rank changed yet")
+	assert.True(t, containsColorIndex(changed.Pix, animationRise), "Some proposal went up")
+	assert.True(t, containsColorIndex(changed.Pix, animationFall), "Some proposal went down")
+}
+
+func TestRenderEvolutionGIF_Failures(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Error(t, RenderEvolutionGIF(buffer, []*PollTally{}, nil), "No snapshots")
+
+	mismatched := evolutionSnapshots()
+	mismatched[1].Proposals = mismatched[1].Proposals[:2]
+	assert.Error(t, RenderEvolutionGIF(buffer, mismatched, nil), "Proposals do not match")
+
+	unbalanced := evolutionSnapshots()
+	unbalanced[2].Proposals[0].Tally[0] = 0
+	assert.Error(t, RenderEvolutionGIF(buffer, unbalanced, nil), "Snapshot is unbalanced")
+}
+
+func containsColorIndex(pixels []uint8, colorIndex uint8) bool {
+	for _, pixel := range pixels {
+		if pixel == colorIndex {
+			return true
+		}
+	}
+	return false
+}
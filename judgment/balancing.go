@@ -0,0 +1,53 @@
+package judgment
+
+import "fmt"
+
+// Kinds of BalancingStrategy
+const (
+	BalancingNone   = "none"   // the tally is expected to be balanced already
+	BalancingStatic = "static" // see PollTally.BalanceWithStaticDefault
+	BalancingMedian = "median" // see PollTally.BalanceWithMedianDefault
+)
+
+// BalancingStrategy describes how to balance a PollTally, as data, so that it may be chosen by users,
+// serialized, and applied later on.
+type BalancingStrategy struct {
+	Kind         string `json:"kind"`                   // BalancingNone, BalancingStatic or BalancingMedian
+	DefaultGrade uint8  `json:"defaultGrade,omitempty"` // only used by BalancingStatic
+}
+
+// Balance applies the strategy to the PollTally, guessing its AmountOfJudges first if it is not set.
+// This method mutates the PollTally.  A nil strategy does nothing.
+func (pollTally *PollTally) Balance(strategy *BalancingStrategy) (err error) {
+	if nil == strategy {
+		return nil
+	}
+	if 0 == pollTally.AmountOfJudges {
+		pollTally.GuessAmountOfJudges()
+	}
+
+	switch strategy.Kind {
+	case BalancingNone, "":
+		return nil
+	case BalancingStatic:
+		return pollTally.BalanceWithStaticDefault(strategy.DefaultGrade)
+	case BalancingMedian:
+		return pollTally.BalanceWithMedianDefault()
+	}
+
+	return fmt.Errorf("Balance() unknown balancing strategy %q", strategy.Kind)
+}
+
+// String describes the strategy for humans, as shown in our reports.
+func (strategy *BalancingStrategy) String() string {
+	if nil == strategy {
+		return ""
+	}
+	switch strategy.Kind {
+	case BalancingStatic:
+		return fmt.Sprintf("static default grade %d", strategy.DefaultGrade)
+	case BalancingMedian:
+		return "median default grade"
+	}
+	return ""
+}
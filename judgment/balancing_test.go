@@ -0,0 +1,70 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPollTally_Balance(t *testing.T) {
+	type test struct {
+		Name     string
+		Strategy *BalancingStrategy
+		Expected [][]uint64
+	}
+	tests := []test{
+		{
+			Name:     "Nil strategy",
+			Strategy: nil,
+			Expected: [][]uint64{{1, 2, 3}, {0, 1, 0}},
+		},
+		{
+			Name:     "None",
+			Strategy: &BalancingStrategy{Kind: BalancingNone},
+			Expected: [][]uint64{{1, 2, 3}, {0, 1, 0}},
+		},
+		{
+			Name:     "Static",
+			Strategy: &BalancingStrategy{Kind: BalancingStatic, DefaultGrade: 2},
+			Expected: [][]uint64{{1, 2, 3}, {0, 1, 5}},
+		},
+		{
+			Name:     "Median",
+			Strategy: &BalancingStrategy{Kind: BalancingMedian},
+			Expected: [][]uint64{{1, 2, 3}, {0, 6, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			poll := &PollTally{
+				Proposals: []*ProposalTally{
+					{Tally: []uint64{1, 2, 3}},
+					{Tally: []uint64{0, 1, 0}},
+				},
+			}
+			err := poll.Balance(tt.Strategy)
+			assert.NoError(t, err, "Balancing should succeed")
+			for proposalIndex, expectedTally := range tt.Expected {
+				assert.Equal(t, expectedTally, poll.Proposals[proposalIndex].Tally)
+			}
+		})
+	}
+}
+
+func TestPollTally_Balance_Failure(t *testing.T) {
+	poll := &PollTally{
+		Proposals: []*ProposalTally{
+			{Tally: []uint64{1, 2, 3}},
+			{Tally: []uint64{0, 1, 0}},
+		},
+	}
+	assert.Error(t, poll.Balance(&BalancingStrategy{Kind: "mean"}), "Unknown strategy")
+	assert.Error(t, poll.Balance(&BalancingStrategy{Kind: BalancingStatic, DefaultGrade: 3}), "Grade too high")
+}
+
+func TestBalancingStrategy_String(t *testing.T) {
+	assert.Equal(t, "static default grade 0", (&BalancingStrategy{Kind: BalancingStatic}).String())
+	assert.Equal(t, "median default grade", (&BalancingStrategy{Kind: BalancingMedian}).String())
+	assert.Equal(t, "", (&BalancingStrategy{Kind: BalancingNone}).String())
+	var noStrategy *BalancingStrategy
+	assert.Equal(t, "", noStrategy.String())
+}
@@ -0,0 +1,66 @@
+package judgment
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Ballot holds the grades given by a single judge, one per proposal, in the order of the proposals.
+// Grades go from 0 ("worst") up to the amount of grades - 1.
+type Ballot []uint8
+
+// TallyBallots counts the ballots into a PollTally of amountOfProposals proposals and amountOfGrades grades.
+// Each ballot must grade all the proposals.
+func TallyBallots(ballots []Ballot, amountOfProposals int, amountOfGrades int) (_ *PollTally, err error) {
+	pollTally := &PollTally{
+		AmountOfJudges: uint64(len(ballots)),
+		Proposals:      make([]*ProposalTally, 0, amountOfProposals),
+	}
+	for proposalIndex := 0; proposalIndex < amountOfProposals; proposalIndex++ {
+		pollTally.Proposals = append(pollTally.Proposals, &ProposalTally{Tally: make([]uint64, amountOfGrades)})
+	}
+
+	for ballotIndex, ballot := range ballots {
+		if amountOfProposals != len(ballot) {
+			return nil, fmt.Errorf("%w: "+
+				"ballot #%d grades %d proposals instead of %d",
+				ErrMishapedTally, ballotIndex, len(ballot), amountOfProposals)
+		}
+		for proposalIndex, grade := range ballot {
+			if int(grade) >= amountOfGrades {
+				return nil, fmt.Errorf("%w: "+
+					"ballot #%d gives grade %d, but there are only %d grades",
+					ErrMishapedTally, ballotIndex, grade, amountOfGrades)
+			}
+			pollTally.Proposals[proposalIndex].Tally[grade]++
+		}
+	}
+
+	return pollTally, nil
+}
+
+// MarshalJSON writes the grades as an array of numbers, rather than the base64 string of a []byte.
+func (ballot Ballot) MarshalJSON() ([]byte, error) {
+	grades := make([]int, 0, len(ballot))
+	for _, grade := range ballot {
+		grades = append(grades, int(grade))
+	}
+	return json.Marshal(grades)
+}
+
+// UnmarshalJSON reads the grades from an array of numbers.
+func (ballot *Ballot) UnmarshalJSON(data []byte) error {
+	grades := []uint8{}
+	numbers := []int{}
+	if err := json.Unmarshal(data, &numbers); nil != err {
+		return err
+	}
+	for _, number := range numbers {
+		if number < 0 || number > 255 {
+			return fmt.Errorf("grade %d of ballot is out of range", number)
+		}
+		grades = append(grades, uint8(number))
+	}
+	*ballot = grades
+	return nil
+}
@@ -0,0 +1,48 @@
+package judgment
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTallyBallots(t *testing.T) {
+	ballots := []Ballot{
+		{0, 2, 1},
+		{2, 2, 0},
+		{1, 2, 0},
+	}
+	pollTally, err := TallyBallots(ballots, 3, 3)
+	assert.NoError(t, err, "Tallying should succeed")
+	assert.Equal(t, uint64(3), pollTally.AmountOfJudges)
+	assert.Equal(t, []uint64{1, 1, 1}, pollTally.Proposals[0].Tally)
+	assert.Equal(t, []uint64{0, 0, 3}, pollTally.Proposals[1].Tally)
+	assert.Equal(t, []uint64{2, 1, 0}, pollTally.Proposals[2].Tally)
+
+	empty, err := TallyBallots(nil, 2, 4)
+	assert.NoError(t, err, "Tallying no ballots should succeed")
+	assert.Equal(t, []uint64{0, 0, 0, 0}, empty.Proposals[1].Tally)
+}
+
+func TestTallyBallots_Failures(t *testing.T) {
+	_, err := TallyBallots([]Ballot{{0, 1}, {0}}, 2, 3)
+	assert.True(t, errors.Is(err, ErrMishapedTally), "Ballots should grade all proposals")
+
+	_, err = TallyBallots([]Ballot{{0, 3}}, 2, 3)
+	assert.True(t, errors.Is(err, ErrMishapedTally), "Grades should exist")
+}
+
+func TestBallot_JSON(t *testing.T) {
+	data, err := json.Marshal([]Ballot{{0, 2, 1}, {}})
+	assert.NoError(t, err)
+	assert.Equal(t, `[[0,2,1],[]]`, string(data), "Ballots should not be base64")
+
+	ballots := []Ballot{}
+	assert.NoError(t, json.Unmarshal([]byte(`[[4, 0], [255]]`), &ballots))
+	assert.Equal(t, []Ballot{{4, 0}, {255}}, ballots)
+
+	assert.Error(t, json.Unmarshal([]byte(`[[256]]`), &ballots))
+	assert.Error(t, json.Unmarshal([]byte(`[[-1]]`), &ballots))
+	assert.Error(t, json.Unmarshal([]byte(`["AAE="]`), &ballots))
+}
@@ -3,8 +3,7 @@ package judgment
 // Some code below is taken directly from colorful's doc examples.
 // https://github.com/lucasb-eyer/go-colorful/blob/master/doc/gradientgen/gradientgen.go
 
-// May be useful later:
-// c, err := colorful.Hex(s)
+// See palette.go for parsing palettes and exporting them to CSS, SCSS and JSON.
 
 import (
 	"errors"
@@ -0,0 +1,168 @@
+package judgment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ResultDiff describes what changed between two deliberations of a poll.
+// Proposals are matched by their Index, that is their position in the input tallies.
+type ResultDiff struct {
+	Added   []*ProposalResult `json:"added"`   // proposals only in the new result
+	Removed []*ProposalResult `json:"removed"` // proposals only in the previous result
+	Changed []*ProposalDiff   `json:"changed"` // proposals in both results whose rank, score or tally changed
+}
+
+// ProposalDiff describes what changed for a single proposal.
+type ProposalDiff struct {
+	Index               int     `json:"index"`
+	PreviousRank        int     `json:"previousRank"`
+	Rank                int     `json:"rank"`
+	PreviousScore       string  `json:"previousScore"`
+	Score               string  `json:"score"`
+	PreviousMedianGrade uint8   `json:"previousMedianGrade"`
+	MedianGrade         uint8   `json:"medianGrade"`
+	TallyDelta          []int64 `json:"tallyDelta"` // amount of judgments gained (or lost) by each grade
+}
+
+// DiffResults compares the previous result a with the new result b.
+func DiffResults(a *PollResult, b *PollResult) (_ *ResultDiff, err error) {
+	if nil == a || nil == b {
+		return nil, fmt.Errorf("DiffResults() needs two results")
+	}
+	diff := &ResultDiff{
+		Added:   []*ProposalResult{},
+		Removed: []*ProposalResult{},
+		Changed: []*ProposalDiff{},
+	}
+	previousByIndex := make(map[int]*ProposalResult, len(a.Proposals))
+	for _, proposalResult := range a.Proposals {
+		previousByIndex[proposalResult.Index] = proposalResult
+	}
+	currentByIndex := make(map[int]*ProposalResult, len(b.Proposals))
+	for _, proposalResult := range b.Proposals {
+		currentByIndex[proposalResult.Index] = proposalResult
+	}
+
+	for _, previous := range a.Proposals {
+		if _, kept := currentByIndex[previous.Index]; !kept {
+			diff.Removed = append(diff.Removed, previous)
+		}
+	}
+	for _, current := range b.Proposals {
+		previous, kept := previousByIndex[current.Index]
+		if !kept {
+			diff.Added = append(diff.Added, current)
+			continue
+		}
+		proposalDiff := &ProposalDiff{
+			Index:         current.Index,
+			PreviousRank:  previous.Rank,
+			Rank:          current.Rank,
+			PreviousScore: previous.Score,
+			Score:         current.Score,
+			TallyDelta:    tallyDelta(previous.Tally, current.Tally),
+		}
+		if nil != previous.Analysis {
+			proposalDiff.PreviousMedianGrade = previous.Analysis.MedianGrade
+		}
+		if nil != current.Analysis {
+			proposalDiff.MedianGrade = current.Analysis.MedianGrade
+		}
+		if !proposalDiff.isEmpty() {
+			diff.Changed = append(diff.Changed, proposalDiff)
+		}
+	}
+
+	return diff, nil
+}
+
+// IsEmpty tells whether both results are the same.
+func (diff *ResultDiff) IsEmpty() bool {
+	return 0 == len(diff.Added) && 0 == len(diff.Removed) && 0 == len(diff.Changed)
+}
+
+// WriteChangelog writes the diff for humans, one paragraph per proposal.
+// options may be nil.
+func (diff *ResultDiff) WriteChangelog(w io.Writer, options *ReportOptions) error {
+	out := ""
+	if diff.IsEmpty() {
+		out += "No changes.\n"
+	}
+	for _, proposalResult := range diff.Added {
+		out += fmt.Sprintf("+ %s was added, ranked %d.\n", options.ProposalName(proposalResult.Index), proposalResult.Rank)
+	}
+	for _, proposalResult := range diff.Removed {
+		out += fmt.Sprintf("- %s was removed, it was ranked %d.\n", options.ProposalName(proposalResult.Index), proposalResult.Rank)
+	}
+	for _, proposalDiff := range diff.Changed {
+		name := options.ProposalName(proposalDiff.Index)
+		switch {
+		case proposalDiff.Rank < proposalDiff.PreviousRank:
+			out += fmt.Sprintf("↑ %s rose from rank %d to rank %d.\n", name, proposalDiff.PreviousRank, proposalDiff.Rank)
+		case proposalDiff.Rank > proposalDiff.PreviousRank:
+			out += fmt.Sprintf("↓ %s fell from rank %d to rank %d.\n", name, proposalDiff.PreviousRank, proposalDiff.Rank)
+		default:
+			out += fmt.Sprintf("= %s stayed at rank %d.\n", name, proposalDiff.Rank)
+		}
+		if proposalDiff.MedianGrade != proposalDiff.PreviousMedianGrade {
+			out += fmt.Sprintf("  median grade: %s → %s\n",
+				options.GradeName(proposalDiff.PreviousMedianGrade), options.GradeName(proposalDiff.MedianGrade))
+		}
+		if proposalDiff.Score != proposalDiff.PreviousScore {
+			out += fmt.Sprintf("  score: %s → %s\n", proposalDiff.PreviousScore, proposalDiff.Score)
+		}
+		deltas := []string{}
+		for grade, delta := range proposalDiff.TallyDelta {
+			if 0 != delta {
+				deltas = append(deltas, fmt.Sprintf("%s %+d", options.GradeName(uint8(grade)), delta))
+			}
+		}
+		if len(deltas) > 0 {
+			out += "  judgments: " + strings.Join(deltas, ", ") + "\n"
+		}
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// isEmpty tells whether nothing changed for the proposal.
+func (proposalDiff *ProposalDiff) isEmpty() bool {
+	if proposalDiff.Rank != proposalDiff.PreviousRank ||
+		proposalDiff.Score != proposalDiff.PreviousScore ||
+		proposalDiff.MedianGrade != proposalDiff.PreviousMedianGrade {
+		return false
+	}
+	for _, delta := range proposalDiff.TallyDelta {
+		if 0 != delta {
+			return false
+		}
+	}
+	return true
+}
+
+// tallyDelta subtracts the previous tally from the current one, grade by grade.
+// Either may be nil, or hold less grades than the other.
+func tallyDelta(previous *ProposalTally, current *ProposalTally) []int64 {
+	previousTally, currentTally := []uint64{}, []uint64{}
+	if nil != previous {
+		previousTally = previous.Tally
+	}
+	if nil != current {
+		currentTally = current.Tally
+	}
+	amountOfGrades := len(currentTally)
+	if len(previousTally) > amountOfGrades {
+		amountOfGrades = len(previousTally)
+	}
+	delta := make([]int64, amountOfGrades)
+	for grade, amount := range currentTally {
+		delta[grade] += int64(amount)
+	}
+	for grade, amount := range previousTally {
+		delta[grade] -= int64(amount)
+	}
+	return delta
+}
@@ -0,0 +1,73 @@
+package judgment
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDiffResults(t *testing.T) {
+	previous := deliberateTallies(t, []uint64{0, 2, 3}, []uint64{1, 3, 1}, []uint64{2, 2, 1})
+	current := deliberateTallies(t, []uint64{0, 2, 3}, []uint64{0, 1, 4})
+
+	diff, err := DiffResults(previous, current)
+	assert.NoError(t, err, "Diff should succeed")
+	assert.False(t, diff.IsEmpty())
+	assert.Empty(t, diff.Added)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, 2, diff.Removed[0].Index)
+	assert.Len(t, diff.Changed, 2, "Both kept proposals changed rank")
+
+	assert.Equal(t, 0, diff.Changed[0].Index)
+	assert.Equal(t, 1, diff.Changed[0].PreviousRank)
+	assert.Equal(t, 2, diff.Changed[0].Rank)
+	assert.Equal(t, []int64{0, 0, 0}, diff.Changed[0].TallyDelta)
+
+	assert.Equal(t, 1, diff.Changed[1].Index)
+	assert.Equal(t, 2, diff.Changed[1].PreviousRank)
+	assert.Equal(t, 1, diff.Changed[1].Rank)
+	assert.Equal(t, uint8(1), diff.Changed[1].PreviousMedianGrade)
+	assert.Equal(t, uint8(2), diff.Changed[1].MedianGrade)
+	assert.Equal(t, []int64{-1, -2, 3}, diff.Changed[1].TallyDelta)
+	assert.NotEqual(t, diff.Changed[1].PreviousScore, diff.Changed[1].Score)
+
+	data, err := json.Marshal(diff)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"tallyDelta":[-1,-2,3]`)
+
+	b := &strings.Builder{}
+	options := &ReportOptions{ProposalNames: []string{"Pizza", "Sushi", "Tacos"}, GradeNames: []string{"bad", "fair", "good"}}
+	assert.NoError(t, diff.WriteChangelog(b, options), "Changelog should be written")
+	changelog := b.String()
+	assert.Contains(t, changelog, "- Tacos was removed, it was ranked 3.\n")
+	assert.Contains(t, changelog, "↓ Pizza fell from rank 1 to rank 2.\n")
+	assert.Contains(t, changelog, "↑ Sushi rose from rank 2 to rank 1.\n")
+	assert.Contains(t, changelog, "  median grade: fair → good\n")
+	assert.Contains(t, changelog, "  judgments: bad -1, fair -2, good +3\n")
+
+	diff, err = DiffResults(current, previous)
+	assert.NoError(t, err)
+	assert.Len(t, diff.Added, 1)
+	b.Reset()
+	assert.NoError(t, diff.WriteChangelog(b, nil))
+	assert.Contains(t, b.String(), "+ Proposal C was added, ranked 3.\n")
+}
+
+func TestDiffResults_Same(t *testing.T) {
+	result := deliberateTallies(t, []uint64{1, 2, 3}, []uint64{3, 2, 1})
+	diff, err := DiffResults(result, deliberateTallies(t, []uint64{1, 2, 3}, []uint64{3, 2, 1}))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	b := &strings.Builder{}
+	assert.NoError(t, diff.WriteChangelog(b, nil))
+	assert.Equal(t, "No changes.\n", b.String())
+
+	_, err = DiffResults(nil, result)
+	assert.Error(t, err)
+}
+
+func TestTallyDelta(t *testing.T) {
+	assert.Equal(t, []int64{1, -1, 2}, tallyDelta(&ProposalTally{Tally: []uint64{1, 2}}, &ProposalTally{Tally: []uint64{2, 1, 2}}))
+	assert.Equal(t, []int64{-1, -2}, tallyDelta(&ProposalTally{Tally: []uint64{1, 2}}, nil))
+}
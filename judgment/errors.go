@@ -0,0 +1,18 @@
+package judgment
+
+import "errors"
+
+// Errors returned by the deliberators, wrapped with more context.
+// Use errors.Is() to tell them apart.
+var (
+	// ErrMishapedTally is returned when some proposals hold more grades than others.
+	ErrMishapedTally = errors.New("mishaped tally")
+	// ErrIncoherentTally is returned when some proposals hold more judgments than there are judges.
+	ErrIncoherentTally = errors.New("incoherent tally")
+	// ErrUnbalancedTally is returned when some proposals hold less judgments than there are judges.
+	ErrUnbalancedTally = errors.New("unbalanced tally")
+	// ErrTooManyJudgments is returned when the amount of judgments overflows our integer types.
+	ErrTooManyJudgments = errors.New("too many judgments")
+	// ErrUnreachableOutcome is returned when no amount of judgments can bring about the requested outcome.
+	ErrUnreachableOutcome = errors.New("unreachable outcome")
+)
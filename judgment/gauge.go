@@ -0,0 +1,80 @@
+package judgment
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// MajorityGauge is the majority gauge (p, α, q) of Balinski and Laraki, with exact proportions:
+// α is the majority grade, p the share of judgments above it, and q the share of judgments below it.
+type MajorityGauge struct {
+	P     *big.Rat `json:"p"`     // share of the adhesion group
+	Alpha uint8    `json:"alpha"` // the majority grade, that is the low median grade
+	Q     *big.Rat `json:"q"`     // share of the contestation group
+}
+
+// Gauge returns the majority gauge of the analyzed proposal.
+func (analysis *ProposalAnalysis) Gauge() (_ *MajorityGauge) {
+	gauge := &MajorityGauge{
+		P:     new(big.Rat),
+		Alpha: analysis.MedianGrade,
+		Q:     new(big.Rat),
+	}
+	if 0 < analysis.TotalSize {
+		total := new(big.Int).SetUint64(analysis.TotalSize)
+		gauge.P.SetFrac(new(big.Int).SetUint64(analysis.AdhesionGroupSize), total)
+		gauge.Q.SetFrac(new(big.Int).SetUint64(analysis.ContestationGroupSize), total)
+	}
+	return gauge
+}
+
+// Sign is +1 when the adhesion group is the biggest (α+), -1 when the contestation group is as big or bigger (α-),
+// like the SecondGroupSign of the ProposalAnalysis, and 0 when all judgments are of the majority grade.
+func (gauge *MajorityGauge) Sign() int {
+	switch gauge.P.Cmp(gauge.Q) {
+	case 1:
+		return 1
+	case 0:
+		if 0 == gauge.Q.Sign() {
+			return 0
+		}
+	}
+	return -1
+}
+
+// String writes the gauge as in the literature, like (2/5, 3+, 1/5).
+func (gauge *MajorityGauge) String() string {
+	sign := ""
+	switch gauge.Sign() {
+	case 1:
+		sign = "+"
+	case -1:
+		sign = "-"
+	}
+	return fmt.Sprintf("(%s, %d%s, %s)", gauge.P.RatString(), gauge.Alpha, sign, gauge.Q.RatString())
+}
+
+// CompareGauges implements the majority gauge ordering: it returns 1 when a ranks above b, -1 when b ranks above a,
+// and 0 when their gauges do not tell them apart, in which case Majority Judgment carries on
+// with the judgments left once the median ones are removed.
+//
+// Balinski and Laraki rank a above b when αa > αb, or when αa = αb and either pa > max(qa, pb, qb)
+// or qb > max(pa, qa, pb).  We compare the majority grades, then p for α+ gauges and -q for α- gauges,
+// which agrees with their rule whenever it decides, and also settles equal shares like ComputeScore does.
+func CompareGauges(a *MajorityGauge, b *MajorityGauge) int {
+	if a.Alpha != b.Alpha {
+		if a.Alpha > b.Alpha {
+			return 1
+		}
+		return -1
+	}
+	return a.signedShare().Cmp(b.signedShare())
+}
+
+// signedShare is p for α+ gauges, and -q for α- gauges.
+func (gauge *MajorityGauge) signedShare() *big.Rat {
+	if gauge.Sign() > 0 {
+		return gauge.P
+	}
+	return new(big.Rat).Neg(gauge.Q)
+}
@@ -0,0 +1,77 @@
+package judgment
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestProposalAnalysis_Gauge(t *testing.T) {
+	gauge := (&ProposalTally{Tally: []uint64{1, 2, 2}}).Analyze().Gauge()
+	assert.Equal(t, uint8(1), gauge.Alpha)
+	assert.Equal(t, big.NewRat(2, 5), gauge.P)
+	assert.Equal(t, big.NewRat(1, 5), gauge.Q)
+	assert.Equal(t, 1, gauge.Sign())
+	assert.Equal(t, "(2/5, 1+, 1/5)", gauge.String())
+
+	gauge = (&ProposalTally{Tally: []uint64{2, 1, 1, 2}}).Analyze().Gauge()
+	assert.Equal(t, "(1/2, 1+, 1/3)", gauge.String(), "Even amounts of judgments use the low median")
+	gauge = (&ProposalTally{Tally: []uint64{1, 2, 1}}).Analyze().Gauge()
+	assert.Equal(t, "(1/4, 1-, 1/4)", gauge.String(), "Equal groups favor contestation")
+	gauge = (&ProposalTally{Tally: []uint64{0, 3, 0}}).Analyze().Gauge()
+	assert.Equal(t, "(0, 1, 0)", gauge.String())
+	gauge = (&ProposalTally{Tally: []uint64{0, 0, 0}}).Analyze().Gauge()
+	assert.Equal(t, "(0, 0, 0)", gauge.String())
+}
+
+func TestCompareGauges(t *testing.T) {
+	gaugeOf := func(tally ...uint64) *MajorityGauge {
+		return (&ProposalTally{Tally: tally}).Analyze().Gauge()
+	}
+	assert.Equal(t, 1, CompareGauges(gaugeOf(0, 1, 2), gaugeOf(0, 2, 1)), "Higher majority grade")
+	assert.Equal(t, 1, CompareGauges(gaugeOf(1, 1, 3), gaugeOf(1, 2, 2)), "Higher majority grade")
+	assert.Equal(t, 1, CompareGauges(gaugeOf(0, 3, 2), gaugeOf(0, 4, 1)), "Bigger adhesion")
+	assert.Equal(t, -1, CompareGauges(gaugeOf(2, 3, 0), gaugeOf(1, 4, 0)), "Bigger contestation")
+	assert.Equal(t, 1, CompareGauges(gaugeOf(0, 4, 1), gaugeOf(1, 4, 0)), "α+ beats α-")
+	assert.Equal(t, 1, CompareGauges(gaugeOf(0, 4, 0), gaugeOf(1, 4, 0)), "α beats α-")
+	assert.Equal(t, -1, CompareGauges(gaugeOf(1, 2, 1), gaugeOf(0, 3, 1)), "Equal shares are α-")
+	assert.Equal(t, 0, CompareGauges(gaugeOf(1, 3, 1), gaugeOf(1, 3, 1)))
+	assert.Equal(t, 0, CompareGauges(gaugeOf(1, 3, 1), gaugeOf(2, 6, 2)), "Only proportions matter")
+}
+
+// The gauge ordering never contradicts the scores of Majority Judgment.
+func TestCompareGauges_MatchScores(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	mj := &MajorityJudgment{}
+	for trial := 0; trial < 2000; trial++ {
+		amountOfGrades := 2 + random.Intn(5)
+		amountOfJudges := 1 + random.Intn(12)
+		tallies := [2]*ProposalTally{}
+		scores := [2]string{}
+		for i := range tallies {
+			tallies[i] = &ProposalTally{Tally: make([]uint64, amountOfGrades)}
+			for judge := 0; judge < amountOfJudges; judge++ {
+				tallies[i].Tally[random.Intn(amountOfGrades)]++
+			}
+			score, err := mj.ComputeScore(tallies[i], true)
+			assert.NoError(t, err)
+			scores[i] = score
+		}
+		comparison := CompareGauges(tallies[0].Analyze().Gauge(), tallies[1].Analyze().Gauge())
+		if 0 == comparison {
+			continue
+		}
+		assert.Equal(t, comparison > 0, scores[0] > scores[1], "Gauges of %v and %v", tallies[0].Tally, tallies[1].Tally)
+	}
+}
+
+func TestPollResult_Describe_Gauge(t *testing.T) {
+	result := deliberateTallies(t, []uint64{1, 2, 2}, []uint64{2, 2, 1})
+	result.Describe()
+	assert.Equal(t, "(2/5, 1+, 1/5)", result.Proposals[0].Gauge.String())
+	data, err := json.Marshal(result.Proposals[0].Gauge)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"p":"2/5","alpha":1,"q":"1/5"}`, string(data))
+}
@@ -21,9 +21,9 @@ func (mj *MajorityJudgment) Deliberate(tally *PollTally) (_ *PollResult, err err
 	amountOfGrades := len(tally.Proposals[0].Tally)
 	for _, proposalTally := range tally.Proposals {
 		if amountOfGrades != len(proposalTally.Tally) {
-			return nil, fmt.Errorf("mishaped tally: " +
-				"some proposals hold more grades than others ; " +
-				"please provide tallies of the same shape")
+			return nil, fmt.Errorf("%w: "+
+				"some proposals hold more grades than others ; "+
+				"please provide tallies of the same shape", ErrMishapedTally)
 		}
 	}
 
@@ -40,17 +40,17 @@ func (mj *MajorityJudgment) Deliberate(tally *PollTally) (_ *PollResult, err err
 		amountOfJudges = tally.GuessAmountOfJudges()
 	}
 	if amountOfJudges < maximumAmountOfJudgments {
-		return nil, fmt.Errorf("incoherent tally: " +
-			"some proposals hold more judgments than the specified amount of judges ; " +
-			"perhaps you forgot to set PollTally.AmountOfJudges " +
-			"or to call PollTally.GuessAmountOfJudges()")
+		return nil, fmt.Errorf("%w: "+
+			"some proposals hold more judgments than the specified amount of judges ; "+
+			"perhaps you forgot to set PollTally.AmountOfJudges "+
+			"or to call PollTally.GuessAmountOfJudges()", ErrIncoherentTally)
 	}
 
 	for proposalIndex, proposalTally := range tally.Proposals {
 		if amountOfJudges != proposalTally.CountJudgments() {
-			return nil, fmt.Errorf("unbalanced tally: "+
+			return nil, fmt.Errorf("%w: "+
 				"a proposal (#%d) holds less judgments than there are judges ; "+
-				"use one of the PollTally.Balance() methods first", proposalIndex)
+				"use one of the PollTally.Balance() methods first", ErrUnbalancedTally, proposalIndex)
 		}
 	}
 
@@ -107,7 +107,7 @@ func (mj *MajorityJudgment) ComputeScore(tally *ProposalTally, favorContestation
 
 	amountOfJudgmentsInt := int(amountOfJudgments)
 	if amountOfJudgmentsInt < 0 {
-		return "", fmt.Errorf("too many judgments ; see branch|fork using math/big")
+		return "", fmt.Errorf("%w ; see branch|fork using math/big", ErrTooManyJudgments)
 	}
 
 	mutatedTally := tally.Copy()
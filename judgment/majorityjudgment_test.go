@@ -1,6 +1,7 @@
 package judgment
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"math"
 	"testing"
@@ -151,6 +152,7 @@ func TestIncoherentTally(t *testing.T) {
 	deliberator := &MajorityJudgment{}
 	result, err := deliberator.Deliberate(poll)
 	assert.Error(t, err, "Deliberation should fail")
+	assert.True(t, errors.Is(err, ErrIncoherentTally), "Error should be a ErrIncoherentTally")
 	assert.Nil(t, result, "Deliberation result should be nil")
 }
 
@@ -165,6 +167,7 @@ func TestMishapedTally(t *testing.T) {
 	deliberator := &MajorityJudgment{}
 	result, err := deliberator.Deliberate(poll)
 	assert.Error(t, err, "Deliberation should fail")
+	assert.True(t, errors.Is(err, ErrMishapedTally), "Error should be a ErrMishapedTally")
 	assert.Nil(t, result, "Deliberation result should be nil")
 }
 
@@ -179,6 +182,7 @@ func TestUnbalancedTally(t *testing.T) {
 	deliberator := &MajorityJudgment{}
 	result, err := deliberator.Deliberate(poll)
 	assert.Error(t, err, "Deliberation should fail")
+	assert.True(t, errors.Is(err, ErrUnbalancedTally), "Error should be a ErrUnbalancedTally")
 	assert.Nil(t, result, "Deliberation result should be nil")
 }
 
@@ -195,6 +199,7 @@ func TestExcessivelyBigTally(t *testing.T) {
 	result, err := deliberator.Deliberate(poll)
 	if assert.Error(t, err, "Deliberation should fail") {
 		//println(err.Error())
+		assert.True(t, errors.Is(err, ErrTooManyJudgments), "Error should be a ErrTooManyJudgments")
 	}
 	assert.Nil(t, result, "Deliberation result should be nil")
 }
@@ -0,0 +1,346 @@
+package judgment
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Goals of a Manipulation
+const (
+	ManipulationWin  = "win"  // the proposal becomes the sole winner
+	ManipulationLose = "lose" // the proposal is no longer ranked first
+)
+
+// DefaultMaxCoalitions is the amount of coalitions tried by default before the search stops being exhaustive.
+const DefaultMaxCoalitions = 100000
+
+// ManipulationOptions tunes the search of manipulations.  Its zero value is usable.
+type ManipulationOptions struct {
+	// MaxCoalitions bounds the search.  When there are more coalitions of judges than that,
+	// we only try a few greedy coalitions and then random ones, so that failures are no proof.
+	MaxCoalitions int
+	Seed          int64 // of the random coalitions
+}
+
+// Manipulation is the outcome of a search: whether a coalition of judges can reach the goal by changing their grades,
+// and, if so, a witness coalition with its new ballots.
+type Manipulation struct {
+	Proposal      int      `json:"proposal"`
+	Goal          string   `json:"goal"` // ManipulationWin or ManipulationLose
+	CoalitionSize int      `json:"coalitionSize"`
+	Found         bool     `json:"found"`
+	Exact         bool     `json:"exact"`             // all coalitions were tried, so that not finding one proves there is none
+	Judges        []int    `json:"judges,omitempty"`  // indices of the ballots of the coalition
+	Ballots       []Ballot `json:"ballots,omitempty"` // their new ballots, in the same order
+	Challenger    int      `json:"challenger"`        // the proposal overtaking the manipulated one when it loses, or -1
+	Rank          int      `json:"rank,omitempty"`    // of the proposal once manipulated
+}
+
+// Manipulability sums up the manipulations of every proposal by coalitions of a given size.
+type Manipulability struct {
+	CoalitionSize int                       `json:"coalitionSize"`
+	Proposals     []*ProposalManipulability `json:"proposals"`
+}
+
+// ProposalManipulability holds the manipulations of a proposal: making it win unless it is the sole winner,
+// and making it lose if it is ranked first.
+type ProposalManipulability struct {
+	Index int           `json:"index"`
+	Rank  int           `json:"rank"`
+	Win   *Manipulation `json:"win,omitempty"`
+	Lose  *Manipulation `json:"lose,omitempty"`
+}
+
+// AnalyzeManipulability searches, for each proposal, whether coalitionSize judges could make it win or lose.
+func (mj *MajorityJudgment) AnalyzeManipulability(
+	ballots []Ballot,
+	amountOfProposals int,
+	amountOfGrades int,
+	coalitionSize int,
+	options *ManipulationOptions,
+) (_ *Manipulability, err error) {
+	pollTally, err := TallyBallots(ballots, amountOfProposals, amountOfGrades)
+	if nil != err {
+		return nil, err
+	}
+	result, err := mj.Deliberate(pollTally)
+	if nil != err {
+		return nil, err
+	}
+	winners := 0
+	for _, proposalResult := range result.Proposals {
+		if 1 == proposalResult.Rank {
+			winners++
+		}
+	}
+
+	manipulability := &Manipulability{
+		CoalitionSize: coalitionSize,
+		Proposals:     make([]*ProposalManipulability, 0, amountOfProposals),
+	}
+	for _, proposalResult := range result.Proposals {
+		proposalManipulability := &ProposalManipulability{Index: proposalResult.Index, Rank: proposalResult.Rank}
+		if proposalResult.Rank > 1 || winners > 1 {
+			proposalManipulability.Win, err = mj.SearchManipulation(
+				ballots, amountOfProposals, amountOfGrades, proposalResult.Index, ManipulationWin, coalitionSize, options)
+			if nil != err {
+				return nil, err
+			}
+		}
+		if 1 == proposalResult.Rank {
+			proposalManipulability.Lose, err = mj.SearchManipulation(
+				ballots, amountOfProposals, amountOfGrades, proposalResult.Index, ManipulationLose, coalitionSize, options)
+			if nil != err {
+				return nil, err
+			}
+		}
+		manipulability.Proposals = append(manipulability.Proposals, proposalManipulability)
+	}
+	return manipulability, nil
+}
+
+// SearchManipulation searches whether coalitionSize judges could bring the proposal to the goal by changing their ballots.
+//
+// Since Majority Judgment is monotonic, a coalition making a proposal win best gives it the best grade
+// and the worst grade to all the others, and a coalition making it lose best gives it the worst grade
+// and the best grade to one challenger.  We therefore only search the coalition, and the challenger.
+// The search is exhaustive when there are at most MaxCoalitions coalitions (times challengers).
+func (mj *MajorityJudgment) SearchManipulation(
+	ballots []Ballot,
+	amountOfProposals int,
+	amountOfGrades int,
+	proposal int,
+	goal string,
+	coalitionSize int,
+	options *ManipulationOptions,
+) (_ *Manipulation, err error) {
+	pollTally, err := TallyBallots(ballots, amountOfProposals, amountOfGrades)
+	if nil != err {
+		return nil, err
+	}
+	if proposal < 0 || proposal >= amountOfProposals {
+		return nil, fmt.Errorf("SearchManipulation() proposal #%d is not in the poll", proposal)
+	}
+	if coalitionSize < 0 {
+		return nil, fmt.Errorf("SearchManipulation() the size of the coalition cannot be negative")
+	}
+	if coalitionSize > len(ballots) {
+		coalitionSize = len(ballots)
+	}
+	maxCoalitions := DefaultMaxCoalitions
+	seed := int64(0)
+	if nil != options {
+		if options.MaxCoalitions > 0 {
+			maxCoalitions = options.MaxCoalitions
+		}
+		seed = options.Seed
+	}
+
+	challengers := []int{-1}
+	if ManipulationLose == goal {
+		challengers = []int{}
+		for challenger := 0; challenger < amountOfProposals; challenger++ {
+			if challenger != proposal {
+				challengers = append(challengers, challenger)
+			}
+		}
+	} else if ManipulationWin != goal {
+		return nil, fmt.Errorf("SearchManipulation() unknown goal %q", goal)
+	}
+
+	search := &manipulationSearch{
+		mj:             mj,
+		ballots:        ballots,
+		pollTally:      pollTally,
+		amountOfGrades: amountOfGrades,
+		proposal:       proposal,
+		goal:           goal,
+	}
+	manipulation := &Manipulation{
+		Proposal:      proposal,
+		Goal:          goal,
+		CoalitionSize: coalitionSize,
+		Challenger:    -1,
+	}
+	if 0 == len(challengers) {
+		manipulation.Exact = true // a lone proposal cannot lose
+		return manipulation, nil
+	}
+	budget := maxCoalitions / len(challengers)
+	if budget < 1 {
+		budget = 1
+	}
+	manipulation.Exact = countCombinations(len(ballots), coalitionSize, budget) <= budget
+	for _, challenger := range challengers {
+		var coalition []int
+		if manipulation.Exact {
+			coalition, err = search.exhaustive(challenger, coalitionSize)
+		} else {
+			coalition, err = search.bounded(challenger, coalitionSize, budget, rand.New(rand.NewSource(seed)))
+		}
+		if nil != err {
+			return nil, err
+		}
+		if nil != coalition {
+			return search.witness(manipulation, challenger, coalition)
+		}
+	}
+	return manipulation, nil
+}
+
+// manipulationSearch holds what the search of a manipulation needs.
+type manipulationSearch struct {
+	mj             *MajorityJudgment
+	ballots        []Ballot
+	pollTally      *PollTally
+	amountOfGrades int
+	proposal       int
+	goal           string
+}
+
+// rewrite returns the best new ballot of a judge of the coalition.
+func (search *manipulationSearch) rewrite(ballot Ballot, challenger int) Ballot {
+	bestGrade := uint8(search.amountOfGrades - 1)
+	rewritten := make(Ballot, len(ballot))
+	if ManipulationWin == search.goal {
+		rewritten[search.proposal] = bestGrade
+		return rewritten
+	}
+	copy(rewritten, ballot)
+	rewritten[search.proposal] = 0
+	rewritten[challenger] = bestGrade
+	return rewritten
+}
+
+// manipulate returns the tally once the coalition rewrote its ballots.
+func (search *manipulationSearch) manipulate(coalition []int, challenger int) *PollTally {
+	manipulated := search.pollTally.Copy()
+	for _, judge := range coalition {
+		for proposal, grade := range search.ballots[judge] {
+			manipulated.Proposals[proposal].Tally[grade]--
+		}
+		for proposal, grade := range search.rewrite(search.ballots[judge], challenger) {
+			manipulated.Proposals[proposal].Tally[grade]++
+		}
+	}
+	return manipulated
+}
+
+// reaches tells whether the coalition reaches the goal.
+func (search *manipulationSearch) reaches(coalition []int, challenger int) (_ bool, err error) {
+	manipulated := search.manipulate(coalition, challenger)
+	if ManipulationLose == search.goal {
+		return search.mj.overtakes(manipulated.Proposals[search.proposal], manipulated.Proposals[challenger])
+	}
+	for other := range manipulated.Proposals {
+		if other == search.proposal {
+			continue
+		}
+		overtakes, err := search.mj.overtakes(manipulated.Proposals[other], manipulated.Proposals[search.proposal])
+		if nil != err || !overtakes {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// exhaustive tries all the coalitions of the size, in lexicographic order.
+func (search *manipulationSearch) exhaustive(challenger int, size int) (_ []int, err error) {
+	coalition := make([]int, size)
+	for i := range coalition {
+		coalition[i] = i
+	}
+	for {
+		reached, err := search.reaches(coalition, challenger)
+		if nil != err {
+			return nil, err
+		}
+		if reached {
+			return coalition, nil
+		}
+		// Next combination
+		i := size - 1
+		for i >= 0 && coalition[i] == len(search.ballots)-size+i {
+			i--
+		}
+		if i < 0 {
+			return nil, nil
+		}
+		coalition[i]++
+		for j := i + 1; j < size; j++ {
+			coalition[j] = coalition[j-1] + 1
+		}
+	}
+}
+
+// bounded tries the greedy coalition, made of the judges whose ballots are the furthest from the goal,
+// and then random coalitions, up to the budget.
+func (search *manipulationSearch) bounded(challenger int, size int, budget int, random *rand.Rand) (_ []int, err error) {
+	judges := make([]int, len(search.ballots))
+	gains := make([]int, len(search.ballots))
+	bestGrade := search.amountOfGrades - 1
+	for judge, ballot := range search.ballots {
+		judges[judge] = judge
+		if ManipulationWin == search.goal {
+			// Raising the proposal counts most, lowering all the others breaks ties.
+			gains[judge] = (bestGrade - int(ballot[search.proposal])) * search.amountOfGrades * len(ballot)
+			for proposal, grade := range ballot {
+				if proposal != search.proposal {
+					gains[judge] += int(grade)
+				}
+			}
+		} else {
+			gains[judge] = int(ballot[search.proposal]) + bestGrade - int(ballot[challenger])
+		}
+	}
+	sort.SliceStable(judges, func(a, b int) bool { return gains[judges[a]] > gains[judges[b]] })
+
+	coalition := append([]int{}, judges[:size]...)
+	for attempt := 0; attempt < budget; attempt++ {
+		sort.Ints(coalition)
+		reached, err := search.reaches(coalition, challenger)
+		if nil != err {
+			return nil, err
+		}
+		if reached {
+			return coalition, nil
+		}
+		coalition = random.Perm(len(search.ballots))[:size]
+	}
+	return nil, nil
+}
+
+// witness fills the manipulation with the coalition reaching the goal.
+func (search *manipulationSearch) witness(manipulation *Manipulation, challenger int, coalition []int) (_ *Manipulation, err error) {
+	manipulation.Found = true
+	manipulation.Judges = append([]int{}, coalition...)
+	manipulation.Ballots = make([]Ballot, 0, len(coalition))
+	for _, judge := range coalition {
+		manipulation.Ballots = append(manipulation.Ballots, search.rewrite(search.ballots[judge], challenger))
+	}
+	if ManipulationLose == search.goal {
+		manipulation.Challenger = challenger
+	}
+	result, err := search.mj.Deliberate(search.manipulate(coalition, challenger))
+	if nil != err {
+		return nil, err
+	}
+	manipulation.Rank = result.Proposals[search.proposal].Rank
+	return manipulation, nil
+}
+
+// countCombinations counts the ways to choose k among n, up to more than limit.
+func countCombinations(n int, k int, limit int) int {
+	if k > n-k {
+		k = n - k
+	}
+	count := 1
+	for i := 1; i <= k; i++ {
+		count = count * (n - k + i) / i
+		if count > limit {
+			return limit + 1
+		}
+	}
+	return count
+}
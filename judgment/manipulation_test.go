@@ -0,0 +1,146 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// replay applies the manipulation to the ballots, and returns the new result.
+func replay(t *testing.T, ballots []Ballot, manipulation *Manipulation, amountOfGrades int) *PollResult {
+	manipulated := append([]Ballot{}, ballots...)
+	for i, judge := range manipulation.Judges {
+		manipulated[judge] = manipulation.Ballots[i]
+	}
+	pollTally, err := TallyBallots(manipulated, len(ballots[0]), amountOfGrades)
+	assert.NoError(t, err)
+	result, err := (&MajorityJudgment{}).Deliberate(pollTally)
+	assert.NoError(t, err)
+	return result
+}
+
+func TestMajorityJudgment_SearchManipulation(t *testing.T) {
+	mj := &MajorityJudgment{}
+	ballots := []Ballot{{2, 1, 0}, {2, 1, 0}, {2, 0, 1}, {1, 2, 0}, {0, 2, 1}}
+
+	manipulation, err := mj.SearchManipulation(ballots, 3, 3, 1, ManipulationWin, 1, nil)
+	assert.NoError(t, err, "Search should succeed")
+	assert.True(t, manipulation.Found, "A single judge should suffice")
+	assert.True(t, manipulation.Exact)
+	assert.Equal(t, -1, manipulation.Challenger)
+	assert.Equal(t, []int{0}, manipulation.Judges)
+	assert.Equal(t, []Ballot{{0, 2, 0}}, manipulation.Ballots)
+	assert.Equal(t, 1, manipulation.Rank)
+	result := replay(t, ballots, manipulation, 3)
+	assert.Equal(t, 1, result.Proposals[1].Rank)
+	assert.Equal(t, 2, result.Proposals[0].Rank)
+
+	manipulation, err = mj.SearchManipulation(ballots, 3, 3, 2, ManipulationWin, 1, nil)
+	assert.NoError(t, err)
+	assert.False(t, manipulation.Found, "A single judge cannot make the last one win")
+	assert.True(t, manipulation.Exact, "The failure should be a proof")
+
+	manipulation, err = mj.SearchManipulation(ballots, 3, 3, 0, ManipulationLose, 1, nil)
+	assert.NoError(t, err)
+	assert.True(t, manipulation.Found)
+	assert.Equal(t, 1, manipulation.Challenger)
+	assert.Equal(t, 2, manipulation.Rank)
+	assert.Equal(t, 2, replay(t, ballots, manipulation, 3).Proposals[0].Rank)
+
+	manipulation, err = mj.SearchManipulation(ballots, 3, 3, 2, ManipulationWin, 0, nil)
+	assert.NoError(t, err)
+	assert.False(t, manipulation.Found)
+	manipulation, err = mj.SearchManipulation(ballots, 3, 3, 2, ManipulationWin, 10, nil)
+	assert.NoError(t, err)
+	assert.True(t, manipulation.Found, "All judges can do anything")
+	assert.Equal(t, 5, manipulation.CoalitionSize)
+}
+
+func TestMajorityJudgment_SearchManipulation_Bounded(t *testing.T) {
+	random := rand.New(rand.NewSource(8))
+	ballots := make([]Ballot, 0, 60)
+	for judge := 0; judge < 60; judge++ {
+		ballots = append(ballots, Ballot{uint8(2 + random.Intn(3)), uint8(random.Intn(4))})
+	}
+	options := &ManipulationOptions{MaxCoalitions: 10, Seed: 1}
+	manipulation, err := (&MajorityJudgment{}).SearchManipulation(ballots, 2, 5, 1, ManipulationWin, 25, options)
+	assert.NoError(t, err)
+	assert.False(t, manipulation.Exact, "There are too many coalitions")
+	assert.True(t, manipulation.Found, "The greedy coalition should suffice")
+	assert.Len(t, manipulation.Judges, 25)
+	assert.Equal(t, 1, replay(t, ballots, manipulation, 5).Proposals[1].Rank)
+}
+
+// Searching coalitions only, with the best rewrites, finds the same manipulations as trying all rewrites.
+func TestMajorityJudgment_SearchManipulation_BruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	mj := &MajorityJudgment{}
+	const amountOfGrades = 3
+	allBallots := []Ballot{}
+	for a := uint8(0); a < amountOfGrades; a++ {
+		for b := uint8(0); b < amountOfGrades; b++ {
+			allBallots = append(allBallots, Ballot{a, b})
+		}
+	}
+	for trial := 0; trial < 40; trial++ {
+		ballots := make([]Ballot, 0, 4)
+		for judge := 0; judge < 2+random.Intn(3); judge++ {
+			ballots = append(ballots, allBallots[random.Intn(len(allBallots))])
+		}
+		for _, goal := range []string{ManipulationWin, ManipulationLose} {
+			manipulation, err := mj.SearchManipulation(ballots, 2, amountOfGrades, 0, goal, 1, nil)
+			assert.NoError(t, err)
+			found := false
+			for judge := range ballots {
+				for _, rewritten := range allBallots {
+					result := replay(t, ballots, &Manipulation{Judges: []int{judge}, Ballots: []Ballot{rewritten}}, amountOfGrades)
+					if ManipulationWin == goal {
+						found = found || (1 == result.Proposals[0].Rank && 2 == result.Proposals[1].Rank)
+					} else {
+						found = found || 1 < result.Proposals[0].Rank
+					}
+				}
+			}
+			assert.Equal(t, found, manipulation.Found, "%s %v", goal, ballots)
+		}
+	}
+}
+
+func TestMajorityJudgment_AnalyzeManipulability(t *testing.T) {
+	ballots := []Ballot{{2, 1, 0}, {2, 1, 0}, {2, 0, 1}, {1, 2, 0}, {0, 2, 1}}
+	manipulability, err := (&MajorityJudgment{}).AnalyzeManipulability(ballots, 3, 3, 1, nil)
+	assert.NoError(t, err, "Analysis should succeed")
+	assert.Equal(t, 1, manipulability.CoalitionSize)
+	assert.Len(t, manipulability.Proposals, 3)
+	assert.Nil(t, manipulability.Proposals[0].Win, "The sole winner cannot be made to win")
+	assert.True(t, manipulability.Proposals[0].Lose.Found)
+	assert.True(t, manipulability.Proposals[1].Win.Found)
+	assert.Nil(t, manipulability.Proposals[1].Lose, "Only winners can be made to lose")
+	assert.False(t, manipulability.Proposals[2].Win.Found)
+}
+
+func TestMajorityJudgment_SearchManipulation_Failures(t *testing.T) {
+	mj := &MajorityJudgment{}
+	ballots := []Ballot{{0, 1}, {1, 0}}
+	_, err := mj.SearchManipulation(ballots, 2, 2, 2, ManipulationWin, 1, nil)
+	assert.Error(t, err)
+	_, err = mj.SearchManipulation(ballots, 2, 2, 0, "bribe", 1, nil)
+	assert.Error(t, err)
+	_, err = mj.SearchManipulation(ballots, 2, 2, 0, ManipulationWin, -1, nil)
+	assert.Error(t, err)
+	_, err = mj.SearchManipulation([]Ballot{{0, 1, 2}}, 2, 3, 0, ManipulationWin, 1, nil)
+	assert.ErrorIs(t, err, ErrMishapedTally)
+	_, err = mj.AnalyzeManipulability([]Ballot{{0, 5}}, 2, 3, 1, nil)
+	assert.ErrorIs(t, err, ErrMishapedTally)
+
+	manipulation, err := mj.SearchManipulation([]Ballot{{0}}, 1, 2, 0, ManipulationLose, 1, nil)
+	assert.NoError(t, err)
+	assert.False(t, manipulation.Found, "A lone proposal cannot lose")
+}
+
+func TestCountCombinations(t *testing.T) {
+	assert.Equal(t, 10, countCombinations(5, 2, 100))
+	assert.Equal(t, 1, countCombinations(5, 0, 100))
+	assert.Equal(t, 1, countCombinations(5, 5, 100))
+	assert.Equal(t, 101, countCombinations(60, 25, 100))
+}
@@ -0,0 +1,284 @@
+package judgment
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of Margin
+const (
+	MarginRegrading = "regrading" // judgments already cast are given another grade
+	MarginAddition  = "addition"  // new judges join the poll
+)
+
+// Margin tells how close a challenger is to overtaking a leader.
+type Margin struct {
+	Kind       string       `json:"kind"`       // MarginRegrading or MarginAddition
+	Leader     int          `json:"leader"`     // index of the proposal to overtake
+	Challenger int          `json:"challenger"` // index of the overtaking proposal
+	Amount     uint64       `json:"amount"`     // minimum amount of judgments to regrade, or of judges to add
+	Regradings []*Regrading `json:"regradings,omitempty"`
+	Additions  []*Addition  `json:"additions,omitempty"`
+	// Scores of both proposals once the changes are made.  The challenger's is higher.
+	LeaderScore     string `json:"leaderScore"`
+	ChallengerScore string `json:"challengerScore"`
+}
+
+// Regrading moves some judgments of a proposal from one grade to another.
+type Regrading struct {
+	Proposal  int    `json:"proposal"`
+	FromGrade uint8  `json:"fromGrade"`
+	ToGrade   uint8  `json:"toGrade"`
+	Amount    uint64 `json:"amount"`
+}
+
+// Addition adds judgments of a grade to a proposal.
+type Addition struct {
+	Proposal int    `json:"proposal"`
+	Grade    uint8  `json:"grade"`
+	Amount   uint64 `json:"amount"`
+}
+
+// ComputeMargin finds the minimum amount of judgments to regrade, or of judges to add,
+// so that the challenger gets a strictly better score than the leader, and one way to do it.
+// Leader and challenger are indices of proposals in the result, whose tallies must be balanced.
+// The amount is zero when the challenger already is ahead.
+//
+// Since Majority Judgment is monotonic, regrading judgments of the challenger to the best grade
+// and judgments of the leader to the worst grade is always the most efficient,
+// starting with the lowest judgments of the challenger and the highest judgments of the leader.
+// We therefore only search how to split the amount between both proposals,
+// in a time proportional to the margin and to the logarithm of the amount of judges.
+func (mj *MajorityJudgment) ComputeMargin(result *PollResult, leader int, challenger int, kind string) (_ *Margin, err error) {
+	if nil == result {
+		return nil, fmt.Errorf("ComputeMargin() needs a result")
+	}
+	if leader < 0 || leader >= len(result.Proposals) || challenger < 0 || challenger >= len(result.Proposals) {
+		return nil, fmt.Errorf("ComputeMargin() proposals #%d and #%d are not in the result", leader, challenger)
+	}
+	if leader == challenger {
+		return nil, fmt.Errorf("ComputeMargin() a proposal cannot overtake itself")
+	}
+	leaderTally := result.Proposals[leader].Tally
+	challengerTally := result.Proposals[challenger].Tally
+	if len(leaderTally.Tally) != len(challengerTally.Tally) {
+		return nil, fmt.Errorf("%w: both proposals should hold the same grades", ErrMishapedTally)
+	}
+	if leaderTally.CountJudgments() != challengerTally.CountJudgments() {
+		return nil, fmt.Errorf("%w: both proposals should hold the same amount of judgments", ErrUnbalancedTally)
+	}
+
+	margin := &Margin{
+		Kind:       kind,
+		Leader:     leader,
+		Challenger: challenger,
+	}
+	switch kind {
+	case MarginRegrading:
+		err = mj.searchRegradingMargin(margin, leaderTally, challengerTally)
+	case MarginAddition:
+		err = mj.searchAdditionMargin(margin, leaderTally, challengerTally)
+	default:
+		err = fmt.Errorf("ComputeMargin() unknown kind of margin %q", kind)
+	}
+	if nil != err {
+		return nil, err
+	}
+	return margin, nil
+}
+
+// ComputeWinnerMargin returns the smallest margin by which another proposal could overtake the winner,
+// that is the first proposal of result.ProposalsSorted.  Tied winners have a margin of one.
+func (mj *MajorityJudgment) ComputeWinnerMargin(result *PollResult, kind string) (_ *Margin, err error) {
+	if nil == result || len(result.ProposalsSorted) < 2 {
+		return nil, fmt.Errorf("ComputeWinnerMargin() needs a result with at least two proposals")
+	}
+	winner := result.ProposalsSorted[0].Index
+	var smallest *Margin
+	for _, proposalResult := range result.Proposals {
+		if winner == proposalResult.Index {
+			continue
+		}
+		margin, err := mj.ComputeMargin(result, winner, proposalResult.Index, kind)
+		if errorIsUnreachable(err) {
+			continue
+		}
+		if nil != err {
+			return nil, err
+		}
+		if nil == smallest || margin.Amount < smallest.Amount {
+			smallest = margin
+		}
+	}
+	if nil == smallest {
+		return nil, fmt.Errorf("%w: no proposal can overtake the winner", ErrUnreachableOutcome)
+	}
+	return smallest, nil
+}
+
+// searchRegradingMargin fills the margin, trying each amount of regraded judgments of the leader,
+// and searching by dichotomy the least amount of regraded judgments of the challenger that suffices.
+func (mj *MajorityJudgment) searchRegradingMargin(margin *Margin, leaderTally *ProposalTally, challengerTally *ProposalTally) (err error) {
+	bestGrade := challengerTally.CountAvailableGrades() - 1
+	raisable := challengerTally.CountJudgments() - challengerTally.Tally[bestGrade]
+	lowerable := leaderTally.CountJudgments() - leaderTally.Tally[0]
+
+	found := false
+	best := uint64(0)
+	bestLowered, bestRaised := uint64(0), uint64(0)
+	for lowered := uint64(0); lowered <= lowerable; lowered++ {
+		if found && lowered >= best {
+			break
+		}
+		overtakes, err := mj.overtakesWhenRegraded(margin, leaderTally, challengerTally, lowered, raisable)
+		if nil != err {
+			return err
+		}
+		if !overtakes {
+			continue
+		}
+		low, high := uint64(0), raisable // the least amount lies within [low, high]
+		for low < high {
+			middle := low + (high-low)/2
+			overtakes, err = mj.overtakesWhenRegraded(margin, leaderTally, challengerTally, lowered, middle)
+			if nil != err {
+				return err
+			}
+			if overtakes {
+				high = middle
+			} else {
+				low = middle + 1
+			}
+		}
+		if !found || lowered+low < best {
+			found = true
+			best = lowered + low
+			margin.Amount = best
+			margin.Regradings = append(
+				lowerJudgments(margin.Leader, leaderTally, lowered),
+				raiseJudgments(margin.Challenger, challengerTally, low)...,
+			)
+			bestLowered, bestRaised = lowered, low
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: proposal #%d cannot overtake proposal #%d", ErrUnreachableOutcome, margin.Challenger, margin.Leader)
+	}
+
+	margin.LeaderScore, err = mj.ComputeScore(
+		applyRegradings(leaderTally, lowerJudgments(margin.Leader, leaderTally, bestLowered)), true)
+	if nil != err {
+		return err
+	}
+	margin.ChallengerScore, err = mj.ComputeScore(
+		applyRegradings(challengerTally, raiseJudgments(margin.Challenger, challengerTally, bestRaised)), true)
+	return err
+}
+
+// overtakesWhenRegraded tells whether the challenger overtakes the leader
+// once the specified amounts of their judgments are lowered and raised.
+func (mj *MajorityJudgment) overtakesWhenRegraded(
+	margin *Margin,
+	leaderTally *ProposalTally,
+	challengerTally *ProposalTally,
+	lowered uint64,
+	raised uint64,
+) (_ bool, err error) {
+	leaderRegraded := applyRegradings(leaderTally, lowerJudgments(margin.Leader, leaderTally, lowered))
+	challengerRegraded := applyRegradings(challengerTally, raiseJudgments(margin.Challenger, challengerTally, raised))
+	return mj.overtakes(leaderRegraded, challengerRegraded)
+}
+
+// searchAdditionMargin fills the margin, adding judges one by one,
+// who give the best grade to the challenger and the worst grade to the leader.
+// Beyond as many new judges as there are judges, the challenger's median grade is the best one
+// and the leader's is the worst one, so the search ends.
+func (mj *MajorityJudgment) searchAdditionMargin(margin *Margin, leaderTally *ProposalTally, challengerTally *ProposalTally) (err error) {
+	bestGrade := challengerTally.CountAvailableGrades() - 1
+	amountOfJudges := leaderTally.CountJudgments()
+	leaderAdded := leaderTally.Copy()
+	challengerAdded := challengerTally.Copy()
+	for added := uint64(0); added <= amountOfJudges+1; added++ {
+		overtakes, err := mj.overtakes(leaderAdded, challengerAdded)
+		if nil != err {
+			return err
+		}
+		if overtakes {
+			margin.Amount = added
+			if added > 0 {
+				margin.Additions = []*Addition{
+					{Proposal: margin.Leader, Grade: 0, Amount: added},
+					{Proposal: margin.Challenger, Grade: bestGrade, Amount: added},
+				}
+			}
+			margin.LeaderScore, err = mj.ComputeScore(leaderAdded, true)
+			if nil != err {
+				return err
+			}
+			margin.ChallengerScore, err = mj.ComputeScore(challengerAdded, true)
+			return err
+		}
+		leaderAdded.Tally[0]++
+		challengerAdded.Tally[bestGrade]++
+	}
+	return fmt.Errorf("%w: proposal #%d cannot overtake proposal #%d", ErrUnreachableOutcome, margin.Challenger, margin.Leader)
+}
+
+// overtakes tells whether the challenger's score is strictly higher than the leader's.
+func (mj *MajorityJudgment) overtakes(leaderTally *ProposalTally, challengerTally *ProposalTally) (_ bool, err error) {
+	leaderScore, err := mj.ComputeScore(leaderTally, true)
+	if nil != err {
+		return false, err
+	}
+	challengerScore, err := mj.ComputeScore(challengerTally, true)
+	if nil != err {
+		return false, err
+	}
+	return challengerScore > leaderScore, nil
+}
+
+// raiseJudgments lists the regradings of the amount of lowest judgments of the proposal to the best grade.
+func raiseJudgments(proposal int, proposalTally *ProposalTally, amount uint64) (regradings []*Regrading) {
+	bestGrade := proposalTally.CountAvailableGrades() - 1
+	for grade := uint8(0); grade < bestGrade && amount > 0; grade++ {
+		moved := minUint64(amount, proposalTally.Tally[grade])
+		if moved > 0 {
+			regradings = append(regradings, &Regrading{Proposal: proposal, FromGrade: grade, ToGrade: bestGrade, Amount: moved})
+			amount -= moved
+		}
+	}
+	return regradings
+}
+
+// lowerJudgments lists the regradings of the amount of highest judgments of the proposal to the worst grade.
+func lowerJudgments(proposal int, proposalTally *ProposalTally, amount uint64) (regradings []*Regrading) {
+	for grade := proposalTally.CountAvailableGrades() - 1; grade > 0 && amount > 0; grade-- {
+		moved := minUint64(amount, proposalTally.Tally[grade])
+		if moved > 0 {
+			regradings = append(regradings, &Regrading{Proposal: proposal, FromGrade: grade, ToGrade: 0, Amount: moved})
+			amount -= moved
+		}
+	}
+	return regradings
+}
+
+// applyRegradings returns a regraded copy of the tally.
+func applyRegradings(proposalTally *ProposalTally, regradings []*Regrading) *ProposalTally {
+	regraded := proposalTally.Copy()
+	for _, regrading := range regradings {
+		regraded.Tally[regrading.FromGrade] -= regrading.Amount
+		regraded.Tally[regrading.ToGrade] += regrading.Amount
+	}
+	return regraded
+}
+
+func errorIsUnreachable(err error) bool {
+	return nil != err && errors.Is(err, ErrUnreachableOutcome)
+}
+
+func minUint64(a uint64, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
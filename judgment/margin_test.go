@@ -0,0 +1,179 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func deliberateTallies(t *testing.T, tallies ...[]uint64) *PollResult {
+	proposalsTallies := make([]*ProposalTally, 0, len(tallies))
+	for _, tally := range tallies {
+		proposalsTallies = append(proposalsTallies, &ProposalTally{Tally: tally})
+	}
+	result, err := (&MajorityJudgment{}).Deliberate(&PollTally{Proposals: proposalsTallies})
+	assert.NoError(t, err, "Deliberation should succeed")
+	return result
+}
+
+func TestMajorityJudgment_ComputeMargin(t *testing.T) {
+	mj := &MajorityJudgment{}
+	result := deliberateTallies(t, []uint64{0, 2, 3}, []uint64{1, 3, 1})
+
+	margin, err := mj.ComputeMargin(result, 0, 1, MarginRegrading)
+	assert.NoError(t, err, "Margin should be found")
+	assert.Equal(t, uint64(2), margin.Amount)
+	assert.Equal(t, []*Regrading{
+		{Proposal: 0, FromGrade: 2, ToGrade: 0, Amount: 1},
+		{Proposal: 1, FromGrade: 0, ToGrade: 2, Amount: 1},
+	}, margin.Regradings)
+	assert.True(t, margin.ChallengerScore > margin.LeaderScore)
+	assert.Equal(t, []uint64{0, 2, 3}, result.Proposals[0].Tally.Tally, "The result should be left intact")
+
+	margin, err = mj.ComputeMargin(result, 0, 1, MarginAddition)
+	assert.NoError(t, err, "Margin should be found")
+	assert.Equal(t, uint64(2), margin.Amount)
+	assert.Equal(t, []*Addition{
+		{Proposal: 0, Grade: 0, Amount: 2},
+		{Proposal: 1, Grade: 2, Amount: 2},
+	}, margin.Additions)
+
+	margin, err = mj.ComputeMargin(result, 1, 0, MarginRegrading)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), margin.Amount, "The challenger already is ahead")
+	assert.Empty(t, margin.Regradings)
+}
+
+func TestMajorityJudgment_ComputeMargin_Tie(t *testing.T) {
+	result := deliberateTallies(t, []uint64{1, 1, 1}, []uint64{1, 1, 1})
+	for _, kind := range []string{MarginRegrading, MarginAddition} {
+		margin, err := (&MajorityJudgment{}).ComputeMargin(result, 0, 1, kind)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), margin.Amount, "A single judgment breaks a tie")
+	}
+}
+
+func TestMajorityJudgment_ComputeWinnerMargin(t *testing.T) {
+	result := deliberateTallies(t, []uint64{0, 0, 5}, []uint64{0, 2, 3}, []uint64{5, 0, 0})
+	margin, err := (&MajorityJudgment{}).ComputeWinnerMargin(result, MarginRegrading)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, margin.Leader)
+	assert.Equal(t, 1, margin.Challenger, "The runner-up should be the closest")
+	assert.Equal(t, uint64(2), margin.Amount)
+}
+
+func TestMajorityJudgment_ComputeMargin_Failures(t *testing.T) {
+	mj := &MajorityJudgment{}
+	result := deliberateTallies(t, []uint64{2}, []uint64{2})
+	_, err := mj.ComputeMargin(result, 0, 1, MarginRegrading)
+	assert.ErrorIs(t, err, ErrUnreachableOutcome, "A single grade leaves no room")
+	_, err = mj.ComputeMargin(result, 0, 1, MarginAddition)
+	assert.ErrorIs(t, err, ErrUnreachableOutcome, "A single grade leaves no room")
+	_, err = mj.ComputeWinnerMargin(result, MarginRegrading)
+	assert.ErrorIs(t, err, ErrUnreachableOutcome)
+
+	_, err = mj.ComputeMargin(result, 0, 0, MarginRegrading)
+	assert.Error(t, err)
+	_, err = mj.ComputeMargin(result, 0, 2, MarginRegrading)
+	assert.Error(t, err)
+	_, err = mj.ComputeMargin(result, 0, 1, "bribery")
+	assert.Error(t, err)
+	_, err = mj.ComputeMargin(nil, 0, 1, MarginRegrading)
+	assert.Error(t, err)
+	_, err = mj.ComputeWinnerMargin(&PollResult{}, MarginRegrading)
+	assert.Error(t, err)
+}
+
+// compositions lists all the tallies of amount judgments over amountOfGrades grades.
+func compositions(amount uint64, amountOfGrades int) [][]uint64 {
+	if 1 == amountOfGrades {
+		return [][]uint64{{amount}}
+	}
+	all := [][]uint64{}
+	for first := uint64(0); first <= amount; first++ {
+		for _, rest := range compositions(amount-first, amountOfGrades-1) {
+			all = append(all, append([]uint64{first}, rest...))
+		}
+	}
+	return all
+}
+
+// distance is the amount of judgments to regrade to turn a tally into another of the same size.
+func distance(a []uint64, b []uint64) (moved uint64) {
+	for grade := range a {
+		if a[grade] > b[grade] {
+			moved += a[grade] - b[grade]
+		}
+	}
+	return moved
+}
+
+// bruteForceMargin tries all the tallies both proposals could end up with.
+func bruteForceMargin(t *testing.T, leader []uint64, challenger []uint64, kind string) (_ uint64, found bool) {
+	mj := &MajorityJudgment{}
+	amountOfJudges := (&ProposalTally{Tally: leader}).CountJudgments()
+	best := uint64(0)
+	for added := uint64(0); added <= amountOfJudges+1; added++ {
+		if MarginRegrading == kind && added > 0 {
+			break
+		}
+		for _, leaderAfter := range compositions(amountOfJudges+added, len(leader)) {
+			for _, challengerAfter := range compositions(amountOfJudges+added, len(challenger)) {
+				amount := added
+				if MarginRegrading == kind {
+					amount = distance(leader, leaderAfter) + distance(challenger, challengerAfter)
+				} else if !contains(leaderAfter, leader) || !contains(challengerAfter, challenger) {
+					continue
+				}
+				if found && amount >= best {
+					continue
+				}
+				overtakes, err := mj.overtakes(&ProposalTally{Tally: leaderAfter}, &ProposalTally{Tally: challengerAfter})
+				assert.NoError(t, err)
+				if overtakes {
+					found = true
+					best = amount
+				}
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return best, found
+}
+
+// contains tells whether a tally holds all the judgments of another.
+func contains(a []uint64, b []uint64) bool {
+	for grade := range a {
+		if a[grade] < b[grade] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMajorityJudgment_ComputeMargin_BruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	mj := &MajorityJudgment{}
+	for trial := 0; trial < 60; trial++ {
+		amountOfGrades := 2 + random.Intn(3)
+		amountOfJudges := uint64(1 + random.Intn(5))
+		all := compositions(amountOfJudges, amountOfGrades)
+		leader := all[random.Intn(len(all))]
+		challenger := all[random.Intn(len(all))]
+		result := deliberateTallies(t, leader, challenger)
+		for _, kind := range []string{MarginRegrading, MarginAddition} {
+			expected, found := bruteForceMargin(t, leader, challenger, kind)
+			margin, err := mj.ComputeMargin(result, 0, 1, kind)
+			if !found {
+				assert.ErrorIs(t, err, ErrUnreachableOutcome)
+				continue
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, margin.Amount, "%s margin of %v over %v", kind, challenger, leader)
+				assert.True(t, margin.ChallengerScore > margin.LeaderScore)
+			}
+		}
+	}
+}
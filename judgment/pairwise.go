@@ -0,0 +1,173 @@
+package judgment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PairwiseOutcome tells how two proposals compare under Majority Judgment.
+type PairwiseOutcome struct {
+	Winner int `json:"winner"` // index of the better proposal, -1 when they are tied
+	// Step of ComputeScore at which the proposals separate, from 0, -1 when they are tied.
+	// Each step compares a median grade, and then the size of the biggest group around it.
+	Step          int  `json:"step"`
+	ByMedianGrade bool `json:"byMedianGrade"` // whether they separate on the median grade, or else on the groups
+}
+
+// PairwiseMatrix compares every pair of proposals of the result.
+// Outcomes are in the order of the input tallies, and matrix[i][j] and matrix[j][i] describe the same duel.
+// The result must come from MajorityJudgment, since its scores are read step by step.
+func PairwiseMatrix(result *PollResult) (matrix [][]PairwiseOutcome, err error) {
+	if nil == result {
+		return nil, fmt.Errorf("PairwiseMatrix() result is nil")
+	}
+	stepWidths := make([]int, 0, len(result.Proposals))
+	gradeWidths := make([]int, 0, len(result.Proposals))
+	for _, proposalResult := range result.Proposals {
+		if nil == proposalResult.Tally {
+			return nil, fmt.Errorf("PairwiseMatrix() proposal #%d has no tally", proposalResult.Index)
+		}
+		gradeWidth := int(countDigitsUint8(proposalResult.Tally.CountAvailableGrades()))
+		stepWidth := gradeWidth + int(countDigitsUint64(proposalResult.Tally.CountJudgments()*2))
+		if len(stepWidths) > 0 && stepWidth != stepWidths[0] {
+			return nil, fmt.Errorf("%w: the proposals' scores cannot be compared step by step", ErrUnbalancedTally)
+		}
+		stepWidths = append(stepWidths, stepWidth)
+		gradeWidths = append(gradeWidths, gradeWidth)
+	}
+
+	matrix = make([][]PairwiseOutcome, len(result.Proposals))
+	for i, a := range result.Proposals {
+		matrix[i] = make([]PairwiseOutcome, len(result.Proposals))
+		for j, b := range result.Proposals {
+			outcome := PairwiseOutcome{Winner: -1, Step: -1}
+			if a.Score != b.Score {
+				outcome.Winner = a.Index
+				if a.Score < b.Score {
+					outcome.Winner = b.Index
+				}
+				separation := 0
+				for separation < len(a.Score) && separation < len(b.Score) && a.Score[separation] == b.Score[separation] {
+					separation++
+				}
+				if stepWidths[i] > 0 {
+					outcome.Step = separation / stepWidths[i]
+					outcome.ByMedianGrade = separation%stepWidths[i] < gradeWidths[i]
+				}
+			}
+			matrix[i][j] = outcome
+		}
+	}
+	return matrix, nil
+}
+
+// CondorcetCheck compares the winners under Majority Judgment with the Condorcet winner of the ballots,
+// the proposal preferred to each other one by more judges than the other way around.
+type CondorcetCheck struct {
+	Duels           [][]uint64 `json:"duels"`           // Duels[i][j] is the amount of ballots grading proposal i above proposal j
+	CondorcetWinner int        `json:"condorcetWinner"` // -1 when there is none
+	Winners         []int      `json:"winners"`         // proposals ranked first by Majority Judgment
+	Consistent      bool       `json:"consistent"`      // whether the sole Majority Judgment winner is the Condorcet winner
+}
+
+// CheckCondorcet counts the duels between proposals on the ballots the result was deliberated from.
+func CheckCondorcet(result *PollResult, ballots []Ballot) (_ *CondorcetCheck, err error) {
+	if nil == result {
+		return nil, fmt.Errorf("CheckCondorcet() result is nil")
+	}
+	amountOfProposals := len(result.Proposals)
+	check := &CondorcetCheck{
+		Duels:           make([][]uint64, amountOfProposals),
+		CondorcetWinner: -1,
+		Winners:         []int{},
+	}
+	for i := range check.Duels {
+		check.Duels[i] = make([]uint64, amountOfProposals)
+	}
+	for ballotIndex, ballot := range ballots {
+		if amountOfProposals != len(ballot) {
+			return nil, fmt.Errorf("%w: "+
+				"ballot #%d grades %d proposals instead of %d",
+				ErrMishapedTally, ballotIndex, len(ballot), amountOfProposals)
+		}
+		for i := range ballot {
+			for j := range ballot {
+				if ballot[i] > ballot[j] {
+					check.Duels[i][j]++
+				}
+			}
+		}
+	}
+
+	for i := range check.Duels {
+		beatsAll := true
+		for j := range check.Duels {
+			if i != j && check.Duels[i][j] <= check.Duels[j][i] {
+				beatsAll = false
+				break
+			}
+		}
+		if beatsAll {
+			check.CondorcetWinner = i
+		}
+	}
+	for _, proposalResult := range result.Proposals {
+		if 1 == proposalResult.Rank {
+			check.Winners = append(check.Winners, proposalResult.Index)
+		}
+	}
+	check.Consistent = 1 == len(check.Winners) && check.Winners[0] == check.CondorcetWinner
+
+	return check, nil
+}
+
+// WritePairwiseDOT writes the dominance graph of the result in the Graphviz DOT language,
+// with an arrow from the better proposal to the other one, labelled with the step at which they separate,
+// and a dashed line between tied proposals.
+// options may be nil.
+func WritePairwiseDOT(w io.Writer, result *PollResult, options *ReportOptions) error {
+	matrix, err := PairwiseMatrix(result)
+	if nil != err {
+		return err
+	}
+
+	title := "Majority Judgment"
+	if nil != options && "" != options.Title {
+		title = options.Title
+	}
+	out := fmt.Sprintf("digraph %s {\n", quoteDOT(title))
+	out += "  node [shape=box];\n"
+	for _, proposalResult := range result.ProposalsSorted {
+		out += fmt.Sprintf("  p%d [label=%s];\n", proposalResult.Index,
+			quoteDOT(fmt.Sprintf("%d. %s", proposalResult.Rank, options.ProposalName(proposalResult.Index))))
+	}
+	for i := range matrix {
+		for j := i + 1; j < len(matrix); j++ {
+			outcome := matrix[i][j]
+			if -1 == outcome.Winner {
+				out += fmt.Sprintf("  p%d -> p%d [dir=none, style=dashed];\n", i, j)
+				continue
+			}
+			loser := i
+			if i == outcome.Winner {
+				loser = j
+			}
+			separation := "groups"
+			if outcome.ByMedianGrade {
+				separation = "median"
+			}
+			out += fmt.Sprintf("  p%d -> p%d [label=%s];\n", outcome.Winner, loser,
+				quoteDOT(fmt.Sprintf("step %d, %s", outcome.Step+1, separation)))
+		}
+	}
+	out += "}\n"
+
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// quoteDOT makes a DOT string literal.
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
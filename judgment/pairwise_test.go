@@ -0,0 +1,92 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPairwiseMatrix(t *testing.T) {
+	result := deliberateTallies(t,
+		[]uint64{0, 2, 3}, // A
+		[]uint64{1, 3, 1}, // B
+		[]uint64{1, 3, 1}, // C, tied with B
+		[]uint64{0, 1, 4}, // D
+	)
+	matrix, err := PairwiseMatrix(result)
+	assert.NoError(t, err, "Matrix should be computed")
+	assert.Len(t, matrix, 4)
+
+	assert.Equal(t, PairwiseOutcome{Winner: 0, Step: 0, ByMedianGrade: true}, matrix[0][1])
+	assert.Equal(t, matrix[0][1], matrix[1][0], "Matrix should be symmetric")
+	assert.Equal(t, PairwiseOutcome{Winner: -1, Step: -1}, matrix[1][2], "Tied proposals never separate")
+	assert.Equal(t, PairwiseOutcome{Winner: -1, Step: -1}, matrix[3][3])
+	assert.Equal(t, PairwiseOutcome{Winner: 3, Step: 0, ByMedianGrade: false}, matrix[0][3],
+		"Same median grades, but a smaller contestation group")
+
+	result = deliberateTallies(t, []uint64{1, 0, 1, 1}, []uint64{0, 1, 1, 1})
+	matrix, err = PairwiseMatrix(result)
+	assert.NoError(t, err)
+	assert.Equal(t, PairwiseOutcome{Winner: 1, Step: 1, ByMedianGrade: true}, matrix[0][1],
+		"Proposals should separate once their median judgments are removed")
+
+	_, err = PairwiseMatrix(nil)
+	assert.Error(t, err)
+	_, err = PairwiseMatrix(&PollResult{Proposals: ProposalsResults{
+		{Score: "1", Tally: &ProposalTally{Tally: []uint64{1, 1}}},
+		{Score: "1", Tally: &ProposalTally{Tally: []uint64{10, 10}}},
+	}})
+	assert.ErrorIs(t, err, ErrUnbalancedTally)
+}
+
+func TestCheckCondorcet(t *testing.T) {
+	mj := &MajorityJudgment{}
+	ballots := []Ballot{{2, 0}, {2, 1}, {1, 2}}
+	pollTally, err := TallyBallots(ballots, 2, 3)
+	assert.NoError(t, err)
+	result, err := mj.Deliberate(pollTally)
+	assert.NoError(t, err)
+	check, err := CheckCondorcet(result, ballots)
+	assert.NoError(t, err, "Check should succeed")
+	assert.Equal(t, [][]uint64{{0, 2}, {1, 0}}, check.Duels)
+	assert.Equal(t, 0, check.CondorcetWinner)
+	assert.Equal(t, []int{0}, check.Winners)
+	assert.True(t, check.Consistent)
+
+	// A majority prefers A, but B has better grades once the medians are removed.
+	ballots = []Ballot{{2, 1}, {3, 2}, {0, 3}}
+	pollTally, err = TallyBallots(ballots, 2, 4)
+	assert.NoError(t, err)
+	result, err = mj.Deliberate(pollTally)
+	assert.NoError(t, err)
+	check, err = CheckCondorcet(result, ballots)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, check.CondorcetWinner)
+	assert.Equal(t, []int{1}, check.Winners)
+	assert.False(t, check.Consistent)
+
+	check, err = CheckCondorcet(result, []Ballot{{1, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, check.CondorcetWinner, "Ties make no Condorcet winner")
+
+	_, err = CheckCondorcet(result, []Ballot{{1, 1, 1}})
+	assert.ErrorIs(t, err, ErrMishapedTally)
+	_, err = CheckCondorcet(nil, ballots)
+	assert.Error(t, err)
+}
+
+func TestWritePairwiseDOT(t *testing.T) {
+	result := deliberateTallies(t, []uint64{0, 2, 3}, []uint64{1, 3, 1}, []uint64{1, 3, 1})
+	options := &ReportOptions{Title: "Lunch", ProposalNames: []string{`"Pizza"`, "Sushi", `Back\slash`}}
+	b := &strings.Builder{}
+	assert.NoError(t, WritePairwiseDOT(b, result, options), "DOT should be written")
+	dot := b.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph \"Lunch\" {\n"))
+	assert.Contains(t, dot, `p0 [label="1. \"Pizza\""];`)
+	assert.Contains(t, dot, `p2 [label="2. Back\\slash"];`)
+	assert.Contains(t, dot, `p0 -> p1 [label="step 1, median"];`)
+	assert.Contains(t, dot, `p1 -> p2 [dir=none, style=dashed];`)
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+
+	assert.Error(t, WritePairwiseDOT(b, nil, nil))
+}
@@ -0,0 +1,339 @@
+package judgment
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/lucasb-eyer/go-colorful"
+	"image/color"
+	"strings"
+)
+
+// ParseColor reads a single color, either in hexadecimal notation ("#df3222", "#f30", with or without the hash)
+// or as a CSS color name ("crimson", "DarkOliveGreen", …).
+func ParseColor(s string) (color.Color, error) {
+	s = strings.TrimSpace(s)
+	if "" == s {
+		return nil, fmt.Errorf("ParseColor() empty color")
+	}
+
+	if hexColor, isNamed := cssNamedColors[strings.ToLower(s)]; isNamed {
+		return hexToRGB(hexColor), nil
+	}
+
+	if !strings.HasPrefix(s, "#") {
+		s = "#" + s
+	}
+	// colorful.Hex is lenient with trailing garbage, so we check the shape ourselves
+	if !isHexColor(s) {
+		return nil, fmt.Errorf("ParseColor() unrecognized color %q", s)
+	}
+	c, err := colorful.Hex(s)
+	if nil != err {
+		return nil, fmt.Errorf("ParseColor() unrecognized color %q", s)
+	}
+
+	return c, nil
+}
+
+// ParsePalette reads a list of colors separated by commas, semicolons or whitespace.
+// Colors may be quoted, so that the output of DumpPaletteHexString can be read back.
+// Looks like: "#df3222", "#ed6f01", "#fab001"  or  red orange #c5d300
+func ParsePalette(s string) (color.Palette, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		switch r {
+		case ',', ';', ' ', '\t', '\n', '\r':
+			return true
+		}
+		return false
+	})
+
+	palette := make(color.Palette, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "\"'`")
+		if "" == field {
+			continue
+		}
+		c, err := ParseColor(field)
+		if nil != err {
+			return nil, err
+		}
+		palette = append(palette, c)
+	}
+
+	return palette, nil
+}
+
+// DumpPaletteCSS dumps the provided palette as CSS custom properties, one per grade.
+// The variables are named after the grade labels, falling back to the grade index when a label is missing.
+// Looks like:
+//
+//	:root {
+//	  --mj-grade-to-reject: #df3222;
+//	  --mj-grade-passable: #ed6f01;
+//	}
+func DumpPaletteCSS(palette color.Palette, gradeLabels []string, prefix string) string {
+	out := ":root {\n"
+	for gradeIndex, gradeColor := range palette {
+		out += fmt.Sprintf(
+			"  --%s: %s;\n",
+			paletteVariableName(prefix, gradeLabels, gradeIndex),
+			DumpColorHexString(gradeColor, "#", false),
+		)
+	}
+	out += "}\n"
+
+	return out
+}
+
+// DefaultPalettePrefix names the SCSS variables of a palette dumped without a prefix.
+const DefaultPalettePrefix = "mj-grade"
+
+// DumpPaletteSCSS dumps the provided palette as SCSS variables, one per grade,
+// followed by a map of all the grades' colors keyed by grade label, in grade order.
+// Looks like:
+//
+//	$mj-grade-to-reject: #df3222;
+//	$mj-grade-passable: #ed6f01;
+//	$mj-grade: ("to reject": #df3222, "passable": #ed6f01);
+//
+// An empty prefix defaults to DefaultPalettePrefix.
+func DumpPaletteSCSS(palette color.Palette, gradeLabels []string, prefix string) string {
+	// SCSS needs a name for the map, and variables should not start with a digit.
+	if "" == slugify(prefix) {
+		prefix = DefaultPalettePrefix
+	}
+	out := ""
+	entries := make([]string, 0, len(palette))
+	for gradeIndex, gradeColor := range palette {
+		hexColor := DumpColorHexString(gradeColor, "#", false)
+		out += fmt.Sprintf("$%s: %s;\n", paletteVariableName(prefix, gradeLabels, gradeIndex), hexColor)
+		entries = append(entries, fmt.Sprintf("%q: %s", paletteLabel(gradeLabels, gradeIndex), hexColor))
+	}
+	out += fmt.Sprintf("$%s: (%s);\n", slugify(prefix), strings.Join(entries, ", "))
+
+	return out
+}
+
+// PaletteEntry is how a single grade's color is exported in JSON.
+type PaletteEntry struct {
+	Grade int    `json:"grade"` // 0 == "worst" grade
+	Label string `json:"label"` // label of the grade, or its index when no label was provided
+	Color string `json:"color"` // hexadecimal, like #df3222
+}
+
+// DumpPaletteJSON dumps the provided palette as a JSON array of PaletteEntry, in grade order.
+// Looks like: [{"grade":0,"label":"to reject","color":"#df3222"}, …]
+func DumpPaletteJSON(palette color.Palette, gradeLabels []string) ([]byte, error) {
+	entries := make([]PaletteEntry, 0, len(palette))
+	for gradeIndex, gradeColor := range palette {
+		entries = append(entries, PaletteEntry{
+			Grade: gradeIndex,
+			Label: paletteLabel(gradeLabels, gradeIndex),
+			Color: DumpColorHexString(gradeColor, "#", false),
+		})
+	}
+
+	return json.Marshal(entries)
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 {
+		return false
+	}
+	for _, r := range s[1:] {
+		isDigit := '0' <= r && r <= '9'
+		isHexLetter := ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+		if !isDigit && !isHexLetter {
+			return false
+		}
+	}
+	return true
+}
+
+func paletteLabel(gradeLabels []string, gradeIndex int) string {
+	if gradeIndex < len(gradeLabels) && "" != strings.TrimSpace(gradeLabels[gradeIndex]) {
+		return gradeLabels[gradeIndex]
+	}
+	return fmt.Sprintf("%d", gradeIndex)
+}
+
+func paletteVariableName(prefix string, gradeLabels []string, gradeIndex int) string {
+	name := slugify(paletteLabel(gradeLabels, gradeIndex))
+	if "" == name {
+		name = fmt.Sprintf("%d", gradeIndex)
+	}
+	if "" != slugify(prefix) {
+		name = slugify(prefix) + "-" + name
+	}
+	return name
+}
+
+// slugify makes a string safe to use as a CSS or SCSS identifier ; "Très bien !" → "tr-s-bien"
+func slugify(s string) string {
+	out := make([]byte, 0, len(s))
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || '_' == r {
+			if dash && len(out) > 0 {
+				out = append(out, '-')
+			}
+			out = append(out, byte(r))
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return string(out)
+}
+
+// The named colors of CSS Color Module Level 4, minus "transparent".
+var cssNamedColors = map[string]int{
+	"aliceblue":            0xf0f8ff,
+	"antiquewhite":         0xfaebd7,
+	"aqua":                 0x00ffff,
+	"aquamarine":           0x7fffd4,
+	"azure":                0xf0ffff,
+	"beige":                0xf5f5dc,
+	"bisque":               0xffe4c4,
+	"black":                0x000000,
+	"blanchedalmond":       0xffebcd,
+	"blue":                 0x0000ff,
+	"blueviolet":           0x8a2be2,
+	"brown":                0xa52a2a,
+	"burlywood":            0xdeb887,
+	"cadetblue":            0x5f9ea0,
+	"chartreuse":           0x7fff00,
+	"chocolate":            0xd2691e,
+	"coral":                0xff7f50,
+	"cornflowerblue":       0x6495ed,
+	"cornsilk":             0xfff8dc,
+	"crimson":              0xdc143c,
+	"cyan":                 0x00ffff,
+	"darkblue":             0x00008b,
+	"darkcyan":             0x008b8b,
+	"darkgoldenrod":        0xb8860b,
+	"darkgray":             0xa9a9a9,
+	"darkgreen":            0x006400,
+	"darkgrey":             0xa9a9a9,
+	"darkkhaki":            0xbdb76b,
+	"darkmagenta":          0x8b008b,
+	"darkolivegreen":       0x556b2f,
+	"darkorange":           0xff8c00,
+	"darkorchid":           0x9932cc,
+	"darkred":              0x8b0000,
+	"darksalmon":           0xe9967a,
+	"darkseagreen":         0x8fbc8f,
+	"darkslateblue":        0x483d8b,
+	"darkslategray":        0x2f4f4f,
+	"darkslategrey":        0x2f4f4f,
+	"darkturquoise":        0x00ced1,
+	"darkviolet":           0x9400d3,
+	"deeppink":             0xff1493,
+	"deepskyblue":          0x00bfff,
+	"dimgray":              0x696969,
+	"dimgrey":              0x696969,
+	"dodgerblue":           0x1e90ff,
+	"firebrick":            0xb22222,
+	"floralwhite":          0xfffaf0,
+	"forestgreen":          0x228b22,
+	"fuchsia":              0xff00ff,
+	"gainsboro":            0xdcdcdc,
+	"ghostwhite":           0xf8f8ff,
+	"gold":                 0xffd700,
+	"goldenrod":            0xdaa520,
+	"gray":                 0x808080,
+	"green":                0x008000,
+	"greenyellow":          0xadff2f,
+	"grey":                 0x808080,
+	"honeydew":             0xf0fff0,
+	"hotpink":              0xff69b4,
+	"indianred":            0xcd5c5c,
+	"indigo":               0x4b0082,
+	"ivory":                0xfffff0,
+	"khaki":                0xf0e68c,
+	"lavender":             0xe6e6fa,
+	"lavenderblush":        0xfff0f5,
+	"lawngreen":            0x7cfc00,
+	"lemonchiffon":         0xfffacd,
+	"lightblue":            0xadd8e6,
+	"lightcoral":           0xf08080,
+	"lightcyan":            0xe0ffff,
+	"lightgoldenrodyellow": 0xfafad2,
+	"lightgray":            0xd3d3d3,
+	"lightgreen":           0x90ee90,
+	"lightgrey":            0xd3d3d3,
+	"lightpink":            0xffb6c1,
+	"lightsalmon":          0xffa07a,
+	"lightseagreen":        0x20b2aa,
+	"lightskyblue":         0x87cefa,
+	"lightslategray":       0x778899,
+	"lightslategrey":       0x778899,
+	"lightsteelblue":       0xb0c4de,
+	"lightyellow":          0xffffe0,
+	"lime":                 0x00ff00,
+	"limegreen":            0x32cd32,
+	"linen":                0xfaf0e6,
+	"magenta":              0xff00ff,
+	"maroon":               0x800000,
+	"mediumaquamarine":     0x66cdaa,
+	"mediumblue":           0x0000cd,
+	"mediumorchid":         0xba55d3,
+	"mediumpurple":         0x9370db,
+	"mediumseagreen":       0x3cb371,
+	"mediumslateblue":      0x7b68ee,
+	"mediumspringgreen":    0x00fa9a,
+	"mediumturquoise":      0x48d1cc,
+	"mediumvioletred":      0xc71585,
+	"midnightblue":         0x191970,
+	"mintcream":            0xf5fffa,
+	"mistyrose":            0xffe4e1,
+	"moccasin":             0xffe4b5,
+	"navajowhite":          0xffdead,
+	"navy":                 0x000080,
+	"oldlace":              0xfdf5e6,
+	"olive":                0x808000,
+	"olivedrab":            0x6b8e23,
+	"orange":               0xffa500,
+	"orangered":            0xff4500,
+	"orchid":               0xda70d6,
+	"palegoldenrod":        0xeee8aa,
+	"palegreen":            0x98fb98,
+	"paleturquoise":        0xafeeee,
+	"palevioletred":        0xdb7093,
+	"papayawhip":           0xffefd5,
+	"peachpuff":            0xffdab9,
+	"peru":                 0xcd853f,
+	"pink":                 0xffc0cb,
+	"plum":                 0xdda0dd,
+	"powderblue":           0xb0e0e6,
+	"purple":               0x800080,
+	"rebeccapurple":        0x663399,
+	"red":                  0xff0000,
+	"rosybrown":            0xbc8f8f,
+	"royalblue":            0x4169e1,
+	"saddlebrown":          0x8b4513,
+	"salmon":               0xfa8072,
+	"sandybrown":           0xf4a460,
+	"seagreen":             0x2e8b57,
+	"seashell":             0xfff5ee,
+	"sienna":               0xa0522d,
+	"silver":               0xc0c0c0,
+	"skyblue":              0x87ceeb,
+	"slateblue":            0x6a5acd,
+	"slategray":            0x708090,
+	"slategrey":            0x708090,
+	"snow":                 0xfffafa,
+	"springgreen":          0x00ff7f,
+	"steelblue":            0x4682b4,
+	"tan":                  0xd2b48c,
+	"teal":                 0x008080,
+	"thistle":              0xd8bfd8,
+	"tomato":               0xff6347,
+	"turquoise":            0x40e0d0,
+	"violet":               0xee82ee,
+	"wheat":                0xf5deb3,
+	"white":                0xffffff,
+	"whitesmoke":           0xf5f5f5,
+	"yellow":               0xffff00,
+	"yellowgreen":          0x9acd32,
+}
@@ -0,0 +1,99 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Hex with hash", input: "#df3222", expected: "#df3222"},
+		{name: "Hex without hash", input: "ed6f01", expected: "#ed6f01"},
+		{name: "Short hex", input: "#f30", expected: "#ff3300"},
+		{name: "Uppercase hex", input: "#FAB001", expected: "#fab001"},
+		{name: "CSS name", input: "crimson", expected: "#dc143c"},
+		{name: "CSS name is case-insensitive", input: "DarkOliveGreen", expected: "#556b2f"},
+		{name: "Surrounding whitespace", input: "  tomato ", expected: "#ff6347"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseColor(tt.input)
+			assert.NoError(t, err, "Parsing should succeed")
+			assert.Equal(t, tt.expected, DumpColorHexString(c, "#", false))
+		})
+	}
+}
+
+func TestParseColor_Failure(t *testing.T) {
+	for _, input := range []string{"", "   ", "#12345", "notacolor", "#gggggg"} {
+		c, err := ParseColor(input)
+		assert.Error(t, err, "Parsing %q should fail", input)
+		assert.Nil(t, c)
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	palette, err := ParsePalette("red; orange,#c5d300\n  '7bbd3e'")
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Equal(t, "#ff0000, #ffa500, #c5d300, #7bbd3e", DumpPaletteHexString(palette, ", ", ""))
+}
+
+func TestParsePalette_RoundTrip(t *testing.T) {
+	original := CreateDefaultPalette(7)
+	dumped := DumpPaletteHexString(original, ", ", "\"")
+	parsed, err := ParsePalette(dumped)
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Equal(t, dumped, DumpPaletteHexString(parsed, ", ", "\""))
+}
+
+func TestParsePalette_Empty(t *testing.T) {
+	palette, err := ParsePalette("  ,, ")
+	assert.NoError(t, err, "Parsing should succeed")
+	assert.Len(t, palette, 0)
+}
+
+func TestParsePalette_Failure(t *testing.T) {
+	palette, err := ParsePalette("#df3222, burgundyish")
+	assert.Error(t, err, "Parsing should fail")
+	assert.Nil(t, palette)
+}
+
+func TestDumpPaletteCSS(t *testing.T) {
+	palette := CreateDefaultPalette(3)
+	out := DumpPaletteCSS(palette, []string{"To Reject", "Passable"}, "mj-grade")
+	assert.Equal(t, ":root {\n"+
+		"  --mj-grade-to-reject: #df3222;\n"+
+		"  --mj-grade-passable: #fab001;\n"+
+		"  --mj-grade-2: #00a249;\n"+
+		"}\n", out)
+}
+
+func TestDumpPaletteSCSS(t *testing.T) {
+	palette := CreateDefaultPalette(2)
+	out := DumpPaletteSCSS(palette, []string{"Très bien !", "Excellent"}, "mj")
+	assert.Equal(t, "$mj-tr-s-bien: #df3222;\n"+
+		"$mj-excellent: #00a249;\n"+
+		"$mj: (\"Très bien !\": #df3222, \"Excellent\": #00a249);\n", out)
+}
+
+func TestDumpPaletteSCSS_EmptyPrefix(t *testing.T) {
+	palette := CreateDefaultPalette(2)
+	out := DumpPaletteSCSS(palette, []string{"1er choix"}, " ")
+	assert.Equal(t, "$mj-grade-1er-choix: #df3222;\n"+
+		"$mj-grade-1: #00a249;\n"+
+		"$mj-grade: (\"1er choix\": #df3222, \"1\": #00a249);\n", out)
+}
+
+func TestDumpPaletteJSON(t *testing.T) {
+	palette := CreateDefaultPalette(2)
+	out, err := DumpPaletteJSON(palette, []string{"bad"})
+	assert.NoError(t, err, "Dumping should succeed")
+	assert.JSONEq(t, `[
+		{"grade": 0, "label": "bad", "color": "#df3222"},
+		{"grade": 1, "label": "1", "color": "#00a249"}
+	]`, string(out))
+}
@@ -0,0 +1,80 @@
+package judgment
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// ReportOptions holds the optional decorations used when writing a PollResult for humans.
+// All fields may be left empty ; sensible defaults are then used.
+type ReportOptions struct {
+	Title         string        // Title of the poll, shown atop the report.
+	ProposalNames []string      // Names of the proposals, in the order of the input tallies.
+	GradeNames    []string      // Names of the grades, from "worst" grade to "best" grade.
+	Palette       color.Palette // One color per grade ; defaults to CreateDefaultPalette.
+	Balancing     string        // Describes the default judgment strategy used to balance the tally, if any.
+}
+
+// ProposalName returns the name of the proposal at the specified index in the input tallies.
+// Defaults to letters, like in our docs: "Proposal A", "Proposal B", …, "Proposal AA", …
+func (options *ReportOptions) ProposalName(proposalIndex int) string {
+	if nil != options && proposalIndex < len(options.ProposalNames) && "" != options.ProposalNames[proposalIndex] {
+		return options.ProposalNames[proposalIndex]
+	}
+	return "Proposal " + lettersFromIndex(proposalIndex)
+}
+
+// GradeName returns the name of the specified grade, or "Grade N" when none was provided.
+func (options *ReportOptions) GradeName(grade uint8) string {
+	if nil != options && int(grade) < len(options.GradeNames) && "" != options.GradeNames[grade] {
+		return options.GradeNames[grade]
+	}
+	return fmt.Sprintf("Grade %d", grade)
+}
+
+// GradesPalette returns the palette to use for the specified amount of grades.
+// The provided Palette is used when it holds enough colors.
+func (options *ReportOptions) GradesPalette(amountOfGrades int) color.Palette {
+	if nil != options && len(options.Palette) >= amountOfGrades {
+		return options.Palette
+	}
+	return CreateDefaultPalette(amountOfGrades)
+}
+
+// countGrades returns the amount of grades of the poll, assuming the tallies are well-shaped.
+func (result *PollResult) countGrades() int {
+	for _, proposalResult := range result.Proposals {
+		if nil != proposalResult.Tally {
+			return int(proposalResult.Tally.CountAvailableGrades())
+		}
+	}
+	return 0
+}
+
+// isTied tells whether the proposal shares its rank with another proposal.
+func (result *PollResult) isTied(proposalResult *ProposalResult) bool {
+	for _, otherResult := range result.Proposals {
+		if otherResult != proposalResult && otherResult.Rank == proposalResult.Rank {
+			return true
+		}
+	}
+	return false
+}
+
+// 0 → A, 25 → Z, 26 → AA, 27 → AB, …
+func lettersFromIndex(index int) string {
+	letters := ""
+	for index >= 0 {
+		letters = string(rune('A'+index%26)) + letters
+		index = index/26 - 1
+	}
+	return letters
+}
+
+// percentOf returns the share of part in total, in percents, and zero for an empty total.
+func percentOf(part uint64, total uint64) float64 {
+	if 0 == total {
+		return 0
+	}
+	return 100.0 * float64(part) / float64(total)
+}
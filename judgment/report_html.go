@@ -0,0 +1,217 @@
+package judgment
+
+import (
+	"fmt"
+	"html/template"
+	"image/color"
+	"io"
+)
+
+// WriteHTMLReport writes a self-contained HTML page describing the PollResult.
+// The page holds the ranking table, the merit profile of each proposal, the median and gauge details,
+// the ties and the balancing strategy used.  CSS is inline, and no network asset is required.
+// options may be nil.
+func WriteHTMLReport(w io.Writer, result *PollResult, options *ReportOptions) error {
+	if nil == result {
+		return fmt.Errorf("WriteHTMLReport() result is nil")
+	}
+
+	return htmlReportTemplate.Execute(w, newHTMLReportView(result, options))
+}
+
+type htmlReportView struct {
+	Title     string
+	Balancing string
+	Grades    []htmlGradeView
+	Proposals []htmlProposalView
+	HasTies   bool
+}
+
+type htmlGradeView struct {
+	Name  string
+	Style template.CSS
+}
+
+type htmlProposalView struct {
+	Rank                  int
+	Index                 int
+	Name                  string
+	Tied                  bool
+	Score                 string
+	TotalSize             uint64
+	MedianGrade           string
+	MedianStyle           template.CSS
+	MedianGroupSize       uint64
+	MedianGroupPercent    string
+	SecondMedianGrade     string
+	SecondGroup           string
+	AdhesionGroupSize     uint64
+	AdhesionPercent       string
+	ContestationGroupSize uint64
+	ContestationPercent   string
+	Profile               []htmlSegmentView
+}
+
+type htmlSegmentView struct {
+	Grade   string
+	Amount  uint64
+	Percent string
+	Style   template.CSS
+}
+
+func newHTMLReportView(result *PollResult, options *ReportOptions) *htmlReportView {
+	amountOfGrades := result.countGrades()
+	palette := options.GradesPalette(amountOfGrades)
+
+	view := &htmlReportView{
+		Title:     "Majority Judgment Results",
+		Grades:    make([]htmlGradeView, 0, amountOfGrades),
+		Proposals: make([]htmlProposalView, 0, len(result.ProposalsSorted)),
+	}
+	if nil != options && "" != options.Title {
+		view.Title = options.Title
+	}
+	if nil != options {
+		view.Balancing = options.Balancing
+	}
+
+	for grade := 0; grade < amountOfGrades; grade++ {
+		view.Grades = append(view.Grades, htmlGradeView{
+			Name:  options.GradeName(uint8(grade)),
+			Style: htmlBackgroundStyle(palette, grade),
+		})
+	}
+
+	for _, proposalResult := range result.ProposalsSorted {
+		proposalView := htmlProposalView{
+			Rank:  proposalResult.Rank,
+			Index: proposalResult.Index,
+			Name:  options.ProposalName(proposalResult.Index),
+			Tied:  result.isTied(proposalResult),
+			Score: proposalResult.Score,
+		}
+		view.HasTies = view.HasTies || proposalView.Tied
+
+		analysis := proposalResult.Analysis
+		if nil != analysis {
+			proposalView.TotalSize = analysis.TotalSize
+			proposalView.MedianGrade = options.GradeName(analysis.MedianGrade)
+			proposalView.MedianStyle = htmlBackgroundStyle(palette, int(analysis.MedianGrade))
+			proposalView.MedianGroupSize = analysis.MedianGroupSize
+			proposalView.MedianGroupPercent = formatPercent(analysis.MedianGroupSize, analysis.TotalSize)
+			proposalView.SecondMedianGrade = options.GradeName(analysis.SecondMedianGrade)
+			proposalView.AdhesionGroupSize = analysis.AdhesionGroupSize
+			proposalView.AdhesionPercent = formatPercent(analysis.AdhesionGroupSize, analysis.TotalSize)
+			proposalView.ContestationGroupSize = analysis.ContestationGroupSize
+			proposalView.ContestationPercent = formatPercent(analysis.ContestationGroupSize, analysis.TotalSize)
+			switch {
+			case analysis.SecondGroupSign > 0:
+				proposalView.SecondGroup = "adhesion"
+			case analysis.SecondGroupSign < 0:
+				proposalView.SecondGroup = "contestation"
+			default:
+				proposalView.SecondGroup = "none"
+			}
+		}
+
+		if nil != proposalResult.Tally {
+			total := proposalResult.Tally.CountJudgments()
+			for grade, amount := range proposalResult.Tally.Tally {
+				if 0 == amount {
+					continue
+				}
+				proposalView.Profile = append(proposalView.Profile, htmlSegmentView{
+					Grade:   options.GradeName(uint8(grade)),
+					Amount:  amount,
+					Percent: formatPercent(amount, total),
+					Style: htmlBackgroundStyle(palette, grade) + template.CSS(fmt.Sprintf(
+						" width: %.4f%%;", percentOf(amount, total),
+					)),
+				})
+			}
+		}
+
+		view.Proposals = append(view.Proposals, proposalView)
+	}
+
+	return view
+}
+
+// The colors are dumped by ourselves as #rrggbb, so they are safe to inline.
+func htmlBackgroundStyle(palette color.Palette, grade int) template.CSS {
+	if grade < 0 || grade >= len(palette) {
+		return template.CSS("background-color: #888888;")
+	}
+	return template.CSS("background-color: " + DumpColorHexString(palette[grade], "#", false) + ";")
+}
+
+func formatPercent(part uint64, total uint64) string {
+	return fmt.Sprintf("%.1f%%", percentOf(part, total))
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: system-ui, -apple-system, "Segoe UI", Roboto, sans-serif; color: #222222; margin: 2em auto; max-width: 60em; padding: 0 1em; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #dddddd; padding: 0.4em 0.6em; text-align: left; vertical-align: middle; }
+th { background-color: #f4f4f4; }
+td.number { text-align: right; font-variant-numeric: tabular-nums; }
+.chip { display: inline-block; width: 0.9em; height: 0.9em; border-radius: 0.2em; margin-right: 0.4em; vertical-align: middle; }
+.tie { font-size: 0.8em; color: #8a5a00; background-color: #fff3d6; border-radius: 0.3em; padding: 0.1em 0.4em; margin-left: 0.4em; }
+.legend { display: flex; flex-wrap: wrap; gap: 1em; margin: 1em 0; padding: 0; list-style: none; }
+.profile { position: relative; display: flex; height: 1.6em; border-radius: 0.2em; overflow: hidden; }
+.profile .segment { height: 100%; }
+.profile .median { position: absolute; left: 50%; top: -0.2em; bottom: -0.2em; border-left: 2px dashed #222222; }
+.proposal { margin-bottom: 1.4em; }
+.proposal .label { margin-bottom: 0.3em; }
+.details { color: #555555; font-size: 0.9em; margin-top: 0.3em; }
+.score { font-family: ui-monospace, monospace; font-size: 0.8em; word-break: break-all; }
+.note { color: #555555; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+{{ if .Balancing }}<p class="note">Balancing strategy: {{ .Balancing }}</p>{{ else }}<p class="note">No balancing strategy was specified.</p>{{ end }}
+{{ if .HasTies }}<p class="note">Some proposals are tied: they share the same rank.</p>{{ end }}
+
+<h2>Ranking</h2>
+<table>
+<thead>
+<tr><th>Rank</th><th>Proposal</th><th>Median grade</th><th>Adhesion</th><th>Contestation</th><th>Judgments</th></tr>
+</thead>
+<tbody>
+{{ range .Proposals }}<tr>
+<td class="number">{{ .Rank }}</td>
+<td>{{ .Name }}{{ if .Tied }}<span class="tie">tie</span>{{ end }}</td>
+<td><span class="chip" style="{{ .MedianStyle }}"></span>{{ .MedianGrade }}</td>
+<td class="number">{{ .AdhesionGroupSize }} ({{ .AdhesionPercent }})</td>
+<td class="number">{{ .ContestationGroupSize }} ({{ .ContestationPercent }})</td>
+<td class="number">{{ .TotalSize }}</td>
+</tr>
+{{ end }}</tbody>
+</table>
+
+<h2>Merit profiles</h2>
+<ul class="legend">
+{{ range .Grades }}<li><span class="chip" style="{{ .Style }}"></span>{{ .Name }}</li>
+{{ end }}</ul>
+{{ range .Proposals }}<div class="proposal">
+<div class="label"><strong>#{{ .Rank }}</strong> {{ .Name }}</div>
+<div class="profile">{{ range .Profile }}<div class="segment" style="{{ .Style }}" title="{{ .Grade }}: {{ .Amount }} ({{ .Percent }})"></div>{{ end }}<div class="median"></div></div>
+<div class="details">
+Median grade <strong>{{ .MedianGrade }}</strong> with {{ .MedianGroupSize }} judgments ({{ .MedianGroupPercent }}) ;
+second median grade <strong>{{ .SecondMedianGrade }}</strong>, from the {{ .SecondGroup }} group.
+</div>
+<div class="details score">Score: {{ .Score }}</div>
+</div>
+{{ end }}
+</body>
+</html>
+`))
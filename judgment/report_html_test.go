@@ -0,0 +1,66 @@
+package judgment
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func deliberateReadmeDemo(t *testing.T) *PollResult {
+	poll := &PollTally{
+		AmountOfJudges: 10,
+		Proposals: []*ProposalTally{
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+			{Tally: []uint64{2, 1, 1, 1, 5}},
+			{Tally: []uint64{2, 1, 1, 2, 4}},
+			{Tally: []uint64{2, 1, 5, 0, 2}},
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+		},
+	}
+	deliberator := &MajorityJudgment{}
+	result, err := deliberator.Deliberate(poll)
+	assert.NoError(t, err, "Deliberation should succeed")
+	return result
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	options := &ReportOptions{
+		Title:         "Lunch <poll>",
+		ProposalNames: []string{"Pizza", "Sushi", "Tacos", "Salad", "Soup & bread"},
+		GradeNames:    []string{"to reject", "poor", "passable", "good", "excellent"},
+		Balancing:     "static default grade: to reject",
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteHTMLReport(buffer, result, options)
+	assert.NoError(t, err, "Writing the report should succeed")
+	html := buffer.String()
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<title>Lunch &lt;poll&gt;</title>", "Names should be escaped")
+	assert.Contains(t, html, "Soup &amp; bread")
+	assert.Contains(t, html, "static default grade: to reject")
+	assert.Contains(t, html, "Some proposals are tied")
+	assert.Contains(t, html, "background-color: #df3222;")
+	assert.NotContains(t, html, "ZgotmplZ", "Inline styles should not be rejected by html/template")
+	assert.NotContains(t, html, "http://", "The report should not require network assets")
+	assert.NotContains(t, html, "https://", "The report should not require network assets")
+	assert.True(t, strings.Index(html, "Sushi") < strings.Index(html, "Tacos"), "Proposals should be sorted by rank")
+}
+
+func TestWriteHTMLReport_NoOptions(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	buffer := &bytes.Buffer{}
+	err := WriteHTMLReport(buffer, result, nil)
+	assert.NoError(t, err, "Writing the report should succeed")
+	assert.Contains(t, buffer.String(), "Proposal B")
+	assert.Contains(t, buffer.String(), "Grade 4")
+}
+
+func TestWriteHTMLReport_NilResult(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := WriteHTMLReport(buffer, nil, nil)
+	assert.Error(t, err, "Writing the report should fail")
+}
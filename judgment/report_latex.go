@@ -0,0 +1,135 @@
+package judgment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTikZ writes a standalone TikZ picture of the merit profiles of the PollResult, sorted by rank.
+// Grade colors are defined with \definecolor from the chosen palette, as mjgrade0, mjgrade1, …
+// The output compiles as is with the standalone document class ; remove the preamble lines if you \input it.
+// options may be nil.
+func WriteTikZ(w io.Writer, result *PollResult, options *ReportOptions) error {
+	if nil == result {
+		return fmt.Errorf("WriteTikZ() result is nil")
+	}
+
+	const barWidth = 10.0  // in cm
+	const barHeight = 0.6  // in cm
+	const barSpacing = 0.3 // in cm
+
+	amountOfGrades := result.countGrades()
+	palette := options.GradesPalette(amountOfGrades)
+
+	out := "\\documentclass[tikz,border=5pt]{standalone}\n"
+	out += "\\usepackage[utf8]{inputenc}\n"
+	out += "\\begin{document}\n"
+	for grade := 0; grade < amountOfGrades; grade++ {
+		out += fmt.Sprintf("\\definecolor{mjgrade%d}{HTML}{%s}\n", grade,
+			strings.ToUpper(DumpColorHexString(palette[grade], "", false)))
+	}
+	out += "\\begin{tikzpicture}\n"
+
+	y := 0.0
+	for _, proposalResult := range result.ProposalsSorted {
+		out += fmt.Sprintf("  \\node[anchor=east] at (-0.2,%.2f) {%d. %s};\n",
+			y-barHeight/2, proposalResult.Rank, EscapeLaTeX(options.ProposalName(proposalResult.Index)))
+		if nil != proposalResult.Tally {
+			total := proposalResult.Tally.CountJudgments()
+			x := 0.0
+			for grade, amount := range proposalResult.Tally.Tally {
+				if 0 == amount {
+					continue
+				}
+				length := barWidth * float64(amount) / float64(total)
+				out += fmt.Sprintf("  \\fill[mjgrade%d] (%.4f,%.2f) rectangle (%.4f,%.2f);\n",
+					grade, x, y, x+length, y-barHeight)
+				x += length
+			}
+		}
+		y -= barHeight + barSpacing
+	}
+
+	out += fmt.Sprintf("  \\draw[dashed,thick] (%.2f,%.2f) -- (%.2f,%.2f);\n",
+		barWidth/2, barSpacing, barWidth/2, y+barHeight)
+
+	// Legend, below the bars
+	legendY := y - barSpacing
+	for grade := 0; grade < amountOfGrades; grade++ {
+		x := barWidth * float64(grade) / float64(amountOfGrades)
+		out += fmt.Sprintf("  \\fill[mjgrade%d] (%.4f,%.2f) rectangle ++(0.3,-0.3);\n", grade, x, legendY)
+		out += fmt.Sprintf("  \\node[anchor=west,font=\\scriptsize] at (%.4f,%.2f) {%s};\n",
+			x+0.35, legendY-0.15, EscapeLaTeX(options.GradeName(uint8(grade))))
+	}
+
+	out += "\\end{tikzpicture}\n"
+	out += "\\end{document}\n"
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// WriteLaTeXTabular writes the PollResult as a LaTeX tabular, sorted by rank.
+// It only needs the xcolor package, for the color chips of the median grades.
+// options may be nil.
+func WriteLaTeXTabular(w io.Writer, result *PollResult, options *ReportOptions) error {
+	if nil == result {
+		return fmt.Errorf("WriteLaTeXTabular() result is nil")
+	}
+
+	amountOfGrades := result.countGrades()
+	palette := options.GradesPalette(amountOfGrades)
+
+	out := "\\begin{tabular}{r l l r r}\n"
+	out += "\\hline\n"
+	out += "Rank & Proposal & Median grade & Adhesion & Contestation \\\\\n"
+	out += "\\hline\n"
+	for _, proposalResult := range result.ProposalsSorted {
+		rank := fmt.Sprintf("%d", proposalResult.Rank)
+		if result.isTied(proposalResult) {
+			rank += "="
+		}
+		median, adhesion, contestation := "", "", ""
+		if nil != proposalResult.Analysis {
+			analysis := proposalResult.Analysis
+			median = EscapeLaTeX(options.GradeName(analysis.MedianGrade))
+			if int(analysis.MedianGrade) < len(palette) {
+				median = fmt.Sprintf("\\textcolor[HTML]{%s}{\\rule{0.8em}{0.8em}} %s",
+					strings.ToUpper(DumpColorHexString(palette[analysis.MedianGrade], "", false)), median)
+			}
+			adhesion = fmt.Sprintf("%d (%s)", analysis.AdhesionGroupSize,
+				EscapeLaTeX(formatPercent(analysis.AdhesionGroupSize, analysis.TotalSize)))
+			contestation = fmt.Sprintf("%d (%s)", analysis.ContestationGroupSize,
+				EscapeLaTeX(formatPercent(analysis.ContestationGroupSize, analysis.TotalSize)))
+		}
+		out += fmt.Sprintf("%s & %s & %s & %s & %s \\\\\n",
+			rank, EscapeLaTeX(options.ProposalName(proposalResult.Index)), median, adhesion, contestation)
+	}
+	out += "\\hline\n"
+	out += "\\end{tabular}\n"
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// EscapeLaTeX escapes the characters of s that have a special meaning in LaTeX text mode.
+func EscapeLaTeX(s string) string {
+	return latexEscaper.Replace(s)
+}
+
+var latexEscaper = strings.NewReplacer(
+	"\\", "\\textbackslash{}",
+	"{", "\\{",
+	"}", "\\}",
+	"$", "\\$",
+	"&", "\\&",
+	"#", "\\#",
+	"%", "\\%",
+	"_", "\\_",
+	"^", "\\textasciicircum{}",
+	"~", "\\textasciitilde{}",
+	"<", "\\textless{}",
+	">", "\\textgreater{}",
+	"|", "\\textbar{}",
+)
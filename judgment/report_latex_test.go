@@ -0,0 +1,55 @@
+package judgment
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEscapeLaTeX(t *testing.T) {
+	assert.Equal(t, "Fish \\& Chips", EscapeLaTeX("Fish & Chips"))
+	assert.Equal(t, "100\\% \\$ \\#1 a\\_b", EscapeLaTeX("100% $ #1 a_b"))
+	assert.Equal(t, "\\textbackslash{}emph\\{x\\}", EscapeLaTeX("\\emph{x}"))
+	assert.Equal(t, "\\textasciitilde{}\\textasciicircum{}", EscapeLaTeX("~^"))
+	assert.Equal(t, "Crème brûlée", EscapeLaTeX("Crème brûlée"))
+}
+
+func TestWriteTikZ(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	options := &ReportOptions{
+		ProposalNames: []string{"A & B", "Sushi", "Tacos", "50% off", "Soup"},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteTikZ(buffer, result, options)
+	assert.NoError(t, err, "Writing the picture should succeed")
+	tex := buffer.String()
+
+	assert.True(t, strings.HasPrefix(tex, "\\documentclass[tikz,border=5pt]{standalone}"))
+	assert.Contains(t, tex, "\\definecolor{mjgrade0}{HTML}{DF3222}")
+	assert.Contains(t, tex, "{1. Sushi}")
+	assert.Contains(t, tex, "{4. A \\& B}")
+	assert.Contains(t, tex, "{3. 50\\% off}")
+	assert.Equal(t, 1, strings.Count(tex, "\\begin{tikzpicture}"))
+	assert.Equal(t, 1, strings.Count(tex, "\\end{tikzpicture}"))
+	// 5 proposals with respectively 5, 5, 5, 4 and 5 non-empty grades, plus 5 legend chips
+	assert.Equal(t, 24+5, strings.Count(tex, "\\fill["))
+}
+
+func TestWriteLaTeXTabular(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	options := &ReportOptions{
+		GradeNames: []string{"to reject", "poor", "passable", "good", "excellent"},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteLaTeXTabular(buffer, result, options)
+	assert.NoError(t, err, "Writing the tabular should succeed")
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+
+	assert.Equal(t, "\\begin{tabular}{r l l r r}", lines[0])
+	assert.Equal(t, "1 & Proposal B & \\textcolor[HTML]{7BBD3E}{\\rule{0.8em}{0.8em}} good & 5 (50.0\\%) & 4 (40.0\\%) \\\\", lines[4])
+	assert.True(t, strings.HasPrefix(lines[7], "4= & Proposal A"))
+	assert.Equal(t, "\\end{tabular}", lines[len(lines)-1])
+}
@@ -0,0 +1,33 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReportOptions_ProposalName(t *testing.T) {
+	options := &ReportOptions{ProposalNames: []string{"Pizza", ""}}
+	assert.Equal(t, "Pizza", options.ProposalName(0))
+	assert.Equal(t, "Proposal B", options.ProposalName(1))
+	assert.Equal(t, "Proposal Z", options.ProposalName(25))
+	assert.Equal(t, "Proposal AA", options.ProposalName(26))
+	assert.Equal(t, "Proposal AB", options.ProposalName(27))
+
+	var noOptions *ReportOptions
+	assert.Equal(t, "Proposal A", noOptions.ProposalName(0))
+}
+
+func TestReportOptions_GradeName(t *testing.T) {
+	options := &ReportOptions{GradeNames: []string{"to reject", "passable"}}
+	assert.Equal(t, "to reject", options.GradeName(0))
+	assert.Equal(t, "passable", options.GradeName(1))
+	assert.Equal(t, "Grade 2", options.GradeName(2))
+}
+
+func TestReportOptions_GradesPalette(t *testing.T) {
+	custom, err := ParsePalette("black, white")
+	assert.NoError(t, err)
+	options := &ReportOptions{Palette: custom}
+	assert.Equal(t, custom, options.GradesPalette(2))
+	assert.Len(t, options.GradesPalette(5), 5, "Too short palettes fall back to the default one")
+}
@@ -0,0 +1,218 @@
+package judgment
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// MeritBarWidth is the amount of characters used by the merit profile mini-bars of the text tables.
+const MeritBarWidth = 20
+
+// meritBarRamp holds the characters of the mini-bars, from "worst" grade to "best" grade.
+var meritBarRamp = []rune{'·', '░', '▒', '▓', '█'}
+
+// WriteMarkdownTable writes the PollResult as a GitHub-flavored Markdown table, sorted by rank.
+// Columns are padded so that the Markdown source is readable as well.
+// options may be nil.
+func WriteMarkdownTable(w io.Writer, result *PollResult, options *ReportOptions) error {
+	if nil == result {
+		return fmt.Errorf("WriteMarkdownTable() result is nil")
+	}
+
+	header, rows, alignRight := textTableCells(result, options)
+	for _, row := range rows {
+		for cellIndex := range row {
+			row[cellIndex] = strings.Replace(row[cellIndex], "|", "\\|", -1)
+		}
+	}
+	widths := textTableWidths(header, rows)
+
+	out := textTableLine(header, widths, alignRight, "| ", " | ", " |")
+	separators := make([]string, len(header))
+	for columnIndex := range header {
+		separator := strings.Repeat("-", widths[columnIndex])
+		if alignRight[columnIndex] {
+			separator = separator[1:] + ":"
+		}
+		separators[columnIndex] = separator
+	}
+	out += "| " + strings.Join(separators, " | ") + " |\n"
+	for _, row := range rows {
+		out += textTableLine(row, widths, alignRight, "| ", " | ", " |")
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// WriteTextTable writes the PollResult as a fixed-width plain-text table, sorted by rank.
+// Wide characters (CJK, emoji) count as two columns so that columns stay aligned in terminals.
+// options may be nil.
+func WriteTextTable(w io.Writer, result *PollResult, options *ReportOptions) error {
+	if nil == result {
+		return fmt.Errorf("WriteTextTable() result is nil")
+	}
+
+	header, rows, alignRight := textTableCells(result, options)
+	widths := textTableWidths(header, rows)
+
+	out := textTableLine(header, widths, alignRight, "", "  ", "")
+	rulers := make([]string, len(header))
+	for columnIndex := range header {
+		rulers[columnIndex] = strings.Repeat("─", widths[columnIndex])
+	}
+	out += strings.Join(rulers, "  ") + "\n"
+	for _, row := range rows {
+		out += textTableLine(row, widths, alignRight, "", "  ", "")
+	}
+
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// MeritBar draws the merit profile of a tally as a mini-bar of the specified width,
+// using lighter characters for "worse" grades.  Looks like: ··········░░▒▒▒▓▓███
+func MeritBar(proposalTally *ProposalTally, width int) string {
+	if nil == proposalTally || width <= 0 {
+		return ""
+	}
+	amountOfGrades := len(proposalTally.Tally)
+	lengths := apportion(proposalTally.Tally, width)
+
+	bar := ""
+	for grade, length := range lengths {
+		rampIndex := len(meritBarRamp) - 1
+		if amountOfGrades > 1 {
+			rampIndex = grade * (len(meritBarRamp) - 1) / (amountOfGrades - 1)
+		}
+		bar += strings.Repeat(string(meritBarRamp[rampIndex]), length)
+	}
+
+	return bar
+}
+
+func textTableCells(result *PollResult, options *ReportOptions) (header []string, rows [][]string, alignRight []bool) {
+	header = []string{"Rank", "Proposal", "Median grade", "Adhesion", "Contestation", "Merit profile"}
+	alignRight = []bool{true, false, false, true, true, false}
+	rows = make([][]string, 0, len(result.ProposalsSorted))
+
+	for _, proposalResult := range result.ProposalsSorted {
+		rank := fmt.Sprintf("%d", proposalResult.Rank)
+		if result.isTied(proposalResult) {
+			rank += "="
+		}
+		row := []string{rank, options.ProposalName(proposalResult.Index), "", "", "", ""}
+		if nil != proposalResult.Analysis {
+			analysis := proposalResult.Analysis
+			row[2] = options.GradeName(analysis.MedianGrade)
+			row[3] = fmt.Sprintf("%d (%s)", analysis.AdhesionGroupSize, formatPercent(analysis.AdhesionGroupSize, analysis.TotalSize))
+			row[4] = fmt.Sprintf("%d (%s)", analysis.ContestationGroupSize, formatPercent(analysis.ContestationGroupSize, analysis.TotalSize))
+		}
+		row[5] = MeritBar(proposalResult.Tally, MeritBarWidth)
+		rows = append(rows, row)
+	}
+
+	return
+}
+
+func textTableWidths(header []string, rows [][]string) []int {
+	widths := make([]int, len(header))
+	for columnIndex, cell := range header {
+		widths[columnIndex] = displayWidth(cell)
+	}
+	for _, row := range rows {
+		for columnIndex, cell := range row {
+			if displayWidth(cell) > widths[columnIndex] {
+				widths[columnIndex] = displayWidth(cell)
+			}
+		}
+	}
+	return widths
+}
+
+func textTableLine(cells []string, widths []int, alignRight []bool, start, between, end string) string {
+	padded := make([]string, len(cells))
+	for columnIndex, cell := range cells {
+		padding := strings.Repeat(" ", widths[columnIndex]-displayWidth(cell))
+		if alignRight[columnIndex] {
+			padded[columnIndex] = padding + cell
+		} else {
+			padded[columnIndex] = cell + padding
+		}
+	}
+	line := start + strings.Join(padded, between) + end
+	if "" == end {
+		line = strings.TrimRight(line, " ")
+	}
+	return line + "\n"
+}
+
+// apportion splits width between the tally's grades proportionally, using the largest remainder method,
+// so that the lengths always add up to exactly width (unless the tally is empty).
+func apportion(tally []uint64, width int) []int {
+	lengths := make([]int, len(tally))
+	total := uint64(0)
+	for _, amount := range tally {
+		total += amount
+	}
+	if 0 == total {
+		return lengths
+	}
+
+	remainders := make([]float64, len(tally))
+	given := 0
+	for grade, amount := range tally {
+		exact := float64(amount) * float64(width) / float64(total)
+		lengths[grade] = int(exact)
+		remainders[grade] = exact - float64(lengths[grade])
+		given += lengths[grade]
+	}
+	for ; given < width; given++ {
+		best := 0
+		for grade := range remainders {
+			if remainders[grade] > remainders[best] {
+				best = grade
+			}
+		}
+		lengths[best]++
+		remainders[best] = -1
+	}
+
+	return lengths
+}
+
+// displayWidth returns the amount of terminal columns used by s.
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth is a coarse implementation of Unicode's East Asian Width ;
+// it covers combining marks, CJK, Hangul, fullwidth forms and most emoji.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r), unicode.Is(unicode.Cf, r):
+		return 0
+	case 0x1100 <= r && r <= 0x115f, // Hangul Jamo
+		0x2e80 <= r && r <= 0x303e,   // CJK radicals, punctuation
+		0x3041 <= r && r <= 0x33ff,   // Kana, CJK compatibility
+		0x3400 <= r && r <= 0x4dbf,   // CJK extension A
+		0x4e00 <= r && r <= 0x9fff,   // CJK unified ideographs
+		0xa000 <= r && r <= 0xa4cf,   // Yi
+		0xac00 <= r && r <= 0xd7a3,   // Hangul syllables
+		0xf900 <= r && r <= 0xfaff,   // CJK compatibility ideographs
+		0xfe30 <= r && r <= 0xfe4f,   // CJK compatibility forms
+		0xff00 <= r && r <= 0xff60,   // fullwidth forms
+		0xffe0 <= r && r <= 0xffe6,   // fullwidth signs
+		0x1f300 <= r && r <= 0x1f64f, // emoji: pictographs, emoticons
+		0x1f900 <= r && r <= 0x1f9ff, // emoji: supplemental pictographs
+		0x20000 <= r && r <= 0x3fffd: // CJK extensions B and beyond
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,71 @@
+package judgment
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdownTable(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	options := &ReportOptions{
+		ProposalNames: []string{"Pizza", "Sushi", "Tacos", "Salad|Soup", "Pasta"},
+		GradeNames:    []string{"to reject", "poor", "passable", "good", "excellent"},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteMarkdownTable(buffer, result, options)
+	assert.NoError(t, err, "Writing the table should succeed")
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+
+	assert.Len(t, lines, 2+5)
+	assert.True(t, strings.HasPrefix(lines[0], "| Rank | Proposal "))
+	assert.True(t, strings.HasPrefix(lines[1], "| ---: | -----"))
+	assert.True(t, strings.HasPrefix(lines[2], "|    1 | Sushi "))
+	assert.Contains(t, lines[2], "| good ")
+	assert.Contains(t, buffer.String(), "Salad\\|Soup", "Pipes should be escaped")
+	assert.Contains(t, lines[5], "4=", "Ties should be marked")
+	for _, line := range lines {
+		assert.Equal(t, displayWidth(lines[0]), displayWidth(line), "Columns should be aligned")
+	}
+}
+
+func TestWriteTextTable_WideNames(t *testing.T) {
+	result := deliberateReadmeDemo(t)
+	options := &ReportOptions{
+		ProposalNames: []string{"寿司", "Crème brûlée", "🍕 Pizza", "Tacos", "Thé"},
+	}
+
+	buffer := &bytes.Buffer{}
+	err := WriteTextTable(buffer, result, options)
+	assert.NoError(t, err, "Writing the table should succeed")
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+
+	assert.Len(t, lines, 2+5)
+	// Every line is trimmed on the right, but the merit profile column is always filled.
+	for _, line := range lines[2:] {
+		assert.Equal(t, displayWidth(lines[1]), displayWidth(line), "Columns should be aligned")
+	}
+	column := strings.Index(lines[0], "Median grade")
+	for _, line := range lines[2:] {
+		prefix := line[:strings.Index(line, "Grade ")]
+		assert.Equal(t, column, displayWidth(prefix), "Median grade column should be aligned")
+	}
+}
+
+func TestMeritBar(t *testing.T) {
+	assert.Equal(t, "····░░░░▒▒▒▒▓▓▓▓████", MeritBar(&ProposalTally{Tally: []uint64{2, 2, 2, 2, 2}}, 20))
+	assert.Equal(t, "··░▒▒▒▒▒██", MeritBar(&ProposalTally{Tally: []uint64{2, 1, 5, 0, 2}}, 10))
+	assert.Equal(t, "", MeritBar(&ProposalTally{Tally: []uint64{0, 0, 0}}, 10))
+	assert.Equal(t, "", MeritBar(nil, 10))
+	assert.Equal(t, 7, len([]rune(MeritBar(&ProposalTally{Tally: []uint64{1, 1, 1}}, 7))))
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 5, displayWidth("Hello"))
+	assert.Equal(t, 4, displayWidth("寿司"))
+	assert.Equal(t, 3, displayWidth("Thé"))
+	assert.Equal(t, 3, displayWidth("Thé")) // combining acute accent
+	assert.Equal(t, 2, displayWidth("🍕"))
+}
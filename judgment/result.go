@@ -13,6 +13,9 @@ type ProposalResult struct {
 	Score    string            `json:"score"` // Higher Score lexicographically → better Rank.
 	Analysis *ProposalAnalysis `json:"analysis"`
 	Tally    *ProposalTally    `json:"tally"` // The tally of grades that generated this result.
+	// Statistics and Gauge are only computed on demand, by PollResult.Describe().
+	Statistics *ProposalStatistics `json:"statistics,omitempty"`
+	Gauge      *MajorityGauge      `json:"gauge,omitempty"`
 }
 
 // ProposalsResults implements sort.Interface based on the Score field.
@@ -0,0 +1,127 @@
+package judgment
+
+import (
+	"fmt"
+)
+
+// Sensitivity tells how many more judgments of each grade a proposal would need to reach a rank.
+type Sensitivity struct {
+	Proposal   int                 `json:"proposal"`
+	TargetRank int                 `json:"targetRank"`
+	Grades     []*GradeSensitivity `json:"grades"`  // one per grade, from "worst" to "best"
+	Minimum    *GradeSensitivity   `json:"minimum"` // the cheapest of the reachable Grades, nil if none is
+}
+
+// GradeSensitivity is the outcome of adding judgments of a single grade to the proposal.
+type GradeSensitivity struct {
+	Grade     uint8      `json:"grade"`
+	Reachable bool       `json:"reachable"`       // whether some amount of judgments of this grade achieves the target
+	Amount    uint64     `json:"amount"`          // minimum amount of additional judgments of this grade
+	Tally     *PollTally `json:"tally,omitempty"` // the alternative tally, balanced
+	Score     string     `json:"score,omitempty"` // of the proposal in the alternative tally
+	Rank      int        `json:"rank,omitempty"`  // of the proposal in the alternative tally, at most the target
+}
+
+// ComputeSensitivity searches, for each grade, the minimum amount of additional judgments of that grade
+// the proposal needs to reach the target rank or better, ties included.
+// The poll tally is left intact ; it may be unbalanced, the balancing strategy being applied to each alternative.
+//
+// Additional judgments first fill the judgments the proposal lacks to match PollTally.AmountOfJudges,
+// and then come from new judges who only judged this proposal, so the other proposals are balanced again.
+// Without a balancing strategy, there can be no new judges.
+// The search is exhaustive up to twice the amount of judges plus one additional judgments,
+// when the median grade of the proposal has long been the added grade.
+// Its cost is therefore proportional to the amount of judges ; prefer ComputeMargin for huge polls.
+func (mj *MajorityJudgment) ComputeSensitivity(
+	pollTally *PollTally,
+	balancing *BalancingStrategy,
+	proposal int,
+	targetRank int,
+) (_ *Sensitivity, err error) {
+	if nil == pollTally {
+		return nil, fmt.Errorf("ComputeSensitivity() needs a tally")
+	}
+	if proposal < 0 || proposal >= len(pollTally.Proposals) {
+		return nil, fmt.Errorf("ComputeSensitivity() proposal #%d is not in the tally", proposal)
+	}
+	if targetRank < 1 || targetRank > len(pollTally.Proposals) {
+		return nil, fmt.Errorf("ComputeSensitivity() rank %d is out of range", targetRank)
+	}
+
+	amountOfJudges := pollTally.AmountOfJudges
+	if 0 == amountOfJudges {
+		amountOfJudges = pollTally.Copy().GuessAmountOfJudges()
+	}
+	sensitivity := &Sensitivity{
+		Proposal:   proposal,
+		TargetRank: targetRank,
+		Grades:     make([]*GradeSensitivity, 0, len(pollTally.Proposals[proposal].Tally)),
+	}
+	unchanged, err := mj.tryAdditions(pollTally, balancing, proposal, 0, 0, amountOfJudges)
+	if nil != err {
+		return nil, err
+	}
+
+	limit := 2*amountOfJudges + 1
+	if nil == balancing || BalancingNone == balancing.Kind || "" == balancing.Kind {
+		limit = amountOfJudges - minUint64(amountOfJudges, pollTally.Proposals[proposal].CountJudgments())
+	}
+	for grade := range pollTally.Proposals[proposal].Tally {
+		gradeSensitivity := &GradeSensitivity{Grade: uint8(grade)}
+		for amount := uint64(0); amount <= limit; amount++ {
+			alternative := unchanged
+			if amount > 0 {
+				alternative, err = mj.tryAdditions(pollTally, balancing, proposal, uint8(grade), amount, amountOfJudges)
+				if nil != err {
+					return nil, err
+				}
+			}
+			if alternative.Rank <= targetRank {
+				gradeSensitivity.Reachable = true
+				gradeSensitivity.Amount = amount
+				gradeSensitivity.Tally = alternative.Tally
+				gradeSensitivity.Score = alternative.Score
+				gradeSensitivity.Rank = alternative.Rank
+				break
+			}
+		}
+		sensitivity.Grades = append(sensitivity.Grades, gradeSensitivity)
+		if gradeSensitivity.Reachable && (nil == sensitivity.Minimum || gradeSensitivity.Amount < sensitivity.Minimum.Amount) {
+			sensitivity.Minimum = gradeSensitivity
+		}
+	}
+
+	return sensitivity, nil
+}
+
+// tryAdditions deliberates a copy of the poll tally where the proposal received the amount of judgments of the grade,
+// and returns the copy, balanced, along with the score and rank of the proposal.
+func (mj *MajorityJudgment) tryAdditions(
+	pollTally *PollTally,
+	balancing *BalancingStrategy,
+	proposal int,
+	grade uint8,
+	amount uint64,
+	amountOfJudges uint64,
+) (_ *GradeSensitivity, err error) {
+	alternative := pollTally.Copy()
+	alternative.AmountOfJudges = amountOfJudges
+	alternative.Proposals[proposal].Tally[grade] += amount
+	if judgments := alternative.Proposals[proposal].CountJudgments(); judgments > amountOfJudges {
+		alternative.AmountOfJudges = judgments
+	}
+	if err = alternative.Balance(balancing); nil != err {
+		return nil, err
+	}
+	result, err := mj.Deliberate(alternative)
+	if nil != err {
+		return nil, err
+	}
+	return &GradeSensitivity{
+		Grade:  grade,
+		Amount: amount,
+		Tally:  alternative,
+		Score:  result.Proposals[proposal].Score,
+		Rank:   result.Proposals[proposal].Rank,
+	}, nil
+}
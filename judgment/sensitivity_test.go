@@ -0,0 +1,81 @@
+package judgment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMajorityJudgment_ComputeSensitivity(t *testing.T) {
+	mj := &MajorityJudgment{}
+	pollTally := &PollTally{
+		Proposals: []*ProposalTally{
+			{Tally: []uint64{0, 1, 4}},
+			{Tally: []uint64{1, 2, 2}},
+			{Tally: []uint64{2, 2, 1}},
+		},
+	}
+	sensitivity, err := mj.ComputeSensitivity(pollTally, nil, 2, 2)
+	assert.NoError(t, err, "Sensitivity should be computed")
+	assert.Nil(t, sensitivity.Minimum, "There can be no new judges without balancing")
+
+	balancing := &BalancingStrategy{Kind: BalancingMedian}
+	sensitivity, err = mj.ComputeSensitivity(pollTally, balancing, 2, 2)
+	assert.NoError(t, err, "Sensitivity should be computed")
+	assert.Len(t, sensitivity.Grades, 3)
+	assert.False(t, sensitivity.Grades[0].Reachable, "Bad judgments never help")
+	assert.False(t, sensitivity.Grades[1].Reachable, "Fair judgments cannot beat a fair median with more adhesion")
+	best := sensitivity.Grades[2]
+	assert.True(t, best.Reachable)
+	assert.Equal(t, uint64(2), best.Amount)
+	assert.Equal(t, 2, best.Rank)
+	assert.Equal(t, []uint64{2, 2, 3}, best.Tally.Proposals[2].Tally)
+	assert.Equal(t, []uint64{0, 1, 6}, best.Tally.Proposals[0].Tally, "New judges should be balanced elsewhere")
+	assert.Equal(t, uint64(7), best.Tally.AmountOfJudges)
+	assert.Equal(t, best, sensitivity.Minimum)
+	assert.Equal(t, []uint64{2, 2, 1}, pollTally.Proposals[2].Tally, "The tally should be left intact")
+
+	// One judgment less should not suffice.
+	alternative, err := mj.tryAdditions(pollTally, balancing, 2, 2, best.Amount-1, 5)
+	assert.NoError(t, err)
+	assert.True(t, alternative.Rank > 2)
+
+	sensitivity, err = mj.ComputeSensitivity(pollTally, nil, 0, 1)
+	assert.NoError(t, err)
+	for _, gradeSensitivity := range sensitivity.Grades {
+		assert.Equal(t, uint64(0), gradeSensitivity.Amount, "The target is already reached")
+	}
+}
+
+func TestMajorityJudgment_ComputeSensitivity_Balancing(t *testing.T) {
+	pollTally := &PollTally{
+		AmountOfJudges: 5,
+		Proposals: []*ProposalTally{
+			{Tally: []uint64{0, 2, 3}},
+			{Tally: []uint64{0, 1, 1}},
+		},
+	}
+	_, err := (&MajorityJudgment{}).ComputeSensitivity(pollTally, nil, 1, 1)
+	assert.ErrorIs(t, err, ErrUnbalancedTally)
+
+	balancing := &BalancingStrategy{Kind: BalancingStatic, DefaultGrade: 0}
+	sensitivity, err := (&MajorityJudgment{}).ComputeSensitivity(pollTally, balancing, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), sensitivity.Minimum.Amount, "Missing judgments should be filled first")
+	assert.Equal(t, uint64(5), sensitivity.Minimum.Tally.AmountOfJudges)
+	assert.Equal(t, []uint64{0, 1, 4}, sensitivity.Minimum.Tally.Proposals[1].Tally)
+}
+
+func TestMajorityJudgment_ComputeSensitivity_Failures(t *testing.T) {
+	mj := &MajorityJudgment{}
+	pollTally := &PollTally{Proposals: []*ProposalTally{{Tally: []uint64{1, 1}}, {Tally: []uint64{2, 0}}}}
+	_, err := mj.ComputeSensitivity(nil, nil, 0, 1)
+	assert.Error(t, err)
+	_, err = mj.ComputeSensitivity(pollTally, nil, 2, 1)
+	assert.Error(t, err)
+	_, err = mj.ComputeSensitivity(pollTally, nil, 1, 0)
+	assert.Error(t, err)
+	_, err = mj.ComputeSensitivity(pollTally, nil, 1, 3)
+	assert.Error(t, err)
+	_, err = mj.ComputeSensitivity(pollTally, &BalancingStrategy{Kind: "mean"}, 1, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package judgment
+
+import (
+	"math"
+)
+
+// ProposalStatistics describes the distribution of the grades of a proposal, beyond its ProposalAnalysis.
+// Grades are handled as numbers, from 0 ("worst") up to the amount of grades - 1,
+// which assumes they are evenly spaced ; Majority Judgment itself does not.
+type ProposalStatistics struct {
+	Mean               float64 `json:"mean"`
+	StandardDeviation  float64 `json:"standardDeviation"`
+	FirstQuartile      uint8   `json:"firstQuartile"` // low quartiles, like the low median
+	ThirdQuartile      uint8   `json:"thirdQuartile"`
+	InterquartileRange uint8   `json:"interquartileRange"`
+	Entropy            float64 `json:"entropy"`           // Shannon entropy of the grades, in bits
+	NormalizedEntropy  float64 `json:"normalizedEntropy"` // from 0 (consensus) to 1 (uniform grades)
+	// Agreement is the measure of Van der Eijk (2001), from -1 (judges split between both extreme grades)
+	// to 1 (all judges give the same grade), 0 meaning uniform grades.
+	Agreement float64 `json:"agreement"`
+	// Bimodality is the coefficient of Sarle, (skewness² + 1) / kurtosis,
+	// which exceeds 5/9 for bimodal distributions.  It is 0 when all judges agree.
+	Bimodality float64 `json:"bimodality"`
+}
+
+// Describe computes the ProposalStatistics of a ProposalTally.  An empty tally yields zero values.
+func (proposalTally *ProposalTally) Describe() (_ *ProposalStatistics) {
+	statistics := &ProposalStatistics{}
+	total := proposalTally.CountJudgments()
+	if 0 == total {
+		return statistics
+	}
+	amountOfGrades := len(proposalTally.Tally)
+
+	for grade, amount := range proposalTally.Tally {
+		statistics.Mean += float64(grade) * float64(amount)
+	}
+	statistics.Mean /= float64(total)
+	var m2, m3, m4 float64 // central moments
+	for grade, amount := range proposalTally.Tally {
+		if 0 == amount {
+			continue
+		}
+		share := float64(amount) / float64(total)
+		deviation := float64(grade) - statistics.Mean
+		m2 += share * deviation * deviation
+		m3 += share * deviation * deviation * deviation
+		m4 += share * deviation * deviation * deviation * deviation
+		statistics.Entropy -= share * math.Log2(share)
+	}
+	statistics.StandardDeviation = math.Sqrt(m2)
+	if amountOfGrades > 1 {
+		statistics.NormalizedEntropy = statistics.Entropy / math.Log2(float64(amountOfGrades))
+	}
+	if m2 > 0 {
+		skewness := m3 / math.Pow(m2, 1.5)
+		kurtosis := m4 / (m2 * m2)
+		statistics.Bimodality = (skewness*skewness + 1) / kurtosis
+	}
+
+	last := total - 1
+	statistics.FirstQuartile = proposalTally.gradeAt(last / 4)
+	statistics.ThirdQuartile = proposalTally.gradeAt(last/4*3 + last%4*3/4)
+	statistics.InterquartileRange = statistics.ThirdQuartile - statistics.FirstQuartile
+	statistics.Agreement = proposalTally.agreement()
+
+	return statistics
+}
+
+// Describe fills the Statistics and the Gauge of each proposal of the result, so that they are included in its JSON.
+func (result *PollResult) Describe() {
+	for _, proposalResult := range result.Proposals {
+		if nil != proposalResult.Tally {
+			proposalResult.Statistics = proposalResult.Tally.Describe()
+		}
+		if nil != proposalResult.Analysis {
+			proposalResult.Gauge = proposalResult.Analysis.Gauge()
+		}
+	}
+}
+
+// gradeAt returns the grade of the judgment at the index, judgments being sorted from "worst" to "best".
+func (proposalTally *ProposalTally) gradeAt(index uint64) uint8 {
+	cursor := uint64(0)
+	for grade, amount := range proposalTally.Tally {
+		cursor += amount
+		if index < cursor {
+			return uint8(grade)
+		}
+	}
+	return proposalTally.CountAvailableGrades() - 1
+}
+
+// agreement computes the measure of Van der Eijk, by splitting the distribution into layers
+// of judgments evenly spread over a pattern of grades, and averaging their agreement weighted by their size.
+func (proposalTally *ProposalTally) agreement() float64 {
+	amountOfGrades := len(proposalTally.Tally)
+	if amountOfGrades < 2 {
+		return 1
+	}
+	remaining := make([]uint64, amountOfGrades)
+	copy(remaining, proposalTally.Tally)
+	total := float64(proposalTally.CountJudgments())
+
+	agreement := 0.0
+	for {
+		layer := uint64(0)
+		pattern := make([]bool, amountOfGrades)
+		amountOfUsedGrades := 0
+		for grade, amount := range remaining {
+			if amount > 0 {
+				pattern[grade] = true
+				amountOfUsedGrades++
+				if 0 == layer || amount < layer {
+					layer = amount
+				}
+			}
+		}
+		if 0 == amountOfUsedGrades {
+			return agreement
+		}
+		for grade := range remaining {
+			if pattern[grade] {
+				remaining[grade] -= layer
+			}
+		}
+		weight := float64(layer) * float64(amountOfUsedGrades) / total
+		agreement += weight * patternAgreement(pattern, amountOfUsedGrades)
+	}
+}
+
+// patternAgreement is the agreement of a layer of Van der Eijk, where the used grades all hold the same amount.
+func patternAgreement(pattern []bool, amountOfUsedGrades int) float64 {
+	k := len(pattern)
+	unimodal, bimodal := 0, 0 // triplets of grades used as 110 or 011, and as 101
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			for l := j + 1; l < k; l++ {
+				switch {
+				case pattern[i] && !pattern[j] && pattern[l]:
+					bimodal++
+				case pattern[i] && pattern[j] && !pattern[l], !pattern[i] && pattern[j] && pattern[l]:
+					unimodal++
+				}
+			}
+		}
+	}
+	unimodality := 1.0
+	if unimodal+bimodal > 0 {
+		unimodality = float64((k-2)*unimodal-(k-1)*bimodal) / float64((k-2)*(unimodal+bimodal))
+	}
+	return unimodality * (1 - float64(amountOfUsedGrades-1)/float64(k-1))
+}
@@ -0,0 +1,85 @@
+package judgment
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProposalTally_Describe(t *testing.T) {
+	type test struct {
+		Name     string
+		Tally    []uint64
+		Expected ProposalStatistics
+	}
+	tests := []test{
+		{
+			Name:     "Consensus",
+			Tally:    []uint64{0, 0, 5},
+			Expected: ProposalStatistics{Mean: 2, FirstQuartile: 2, ThirdQuartile: 2, Agreement: 1},
+		},
+		{
+			Name:  "Uniform",
+			Tally: []uint64{1, 1, 1, 1},
+			Expected: ProposalStatistics{
+				Mean: 1.5, StandardDeviation: 1.118034, FirstQuartile: 0, ThirdQuartile: 2, InterquartileRange: 2,
+				Entropy: 2, NormalizedEntropy: 1, Agreement: 0, Bimodality: 0.609756,
+			},
+		},
+		{
+			Name:  "Polarized",
+			Tally: []uint64{5, 0, 0, 5},
+			Expected: ProposalStatistics{
+				Mean: 1.5, StandardDeviation: 1.5, FirstQuartile: 0, ThirdQuartile: 3, InterquartileRange: 3,
+				Entropy: 1, NormalizedEntropy: 0.5, Agreement: -1, Bimodality: 1,
+			},
+		},
+		{
+			Name:  "Layers",
+			Tally: []uint64{2, 1, 0},
+			Expected: ProposalStatistics{
+				Mean: 1.0 / 3, StandardDeviation: 0.471405, FirstQuartile: 0, ThirdQuartile: 0, InterquartileRange: 0,
+				Entropy: 0.918296, NormalizedEntropy: 0.579380, Agreement: 2.0 / 3, Bimodality: 1,
+			},
+		},
+		{
+			Name:     "Empty",
+			Tally:    []uint64{0, 0, 0},
+			Expected: ProposalStatistics{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			statistics := (&ProposalTally{Tally: tt.Tally}).Describe()
+			assert.InDelta(t, tt.Expected.Mean, statistics.Mean, 1e-6, "Mean")
+			assert.InDelta(t, tt.Expected.StandardDeviation, statistics.StandardDeviation, 1e-6, "Standard deviation")
+			assert.Equal(t, tt.Expected.FirstQuartile, statistics.FirstQuartile, "First quartile")
+			assert.Equal(t, tt.Expected.ThirdQuartile, statistics.ThirdQuartile, "Third quartile")
+			assert.Equal(t, tt.Expected.InterquartileRange, statistics.InterquartileRange, "Interquartile range")
+			assert.InDelta(t, tt.Expected.Entropy, statistics.Entropy, 1e-6, "Entropy")
+			assert.InDelta(t, tt.Expected.NormalizedEntropy, statistics.NormalizedEntropy, 1e-6, "Normalized entropy")
+			assert.InDelta(t, tt.Expected.Agreement, statistics.Agreement, 1e-6, "Agreement")
+			assert.InDelta(t, tt.Expected.Bimodality, statistics.Bimodality, 1e-6, "Bimodality")
+		})
+	}
+}
+
+func TestPatternAgreement(t *testing.T) {
+	assert.InDelta(t, 2.0/3, patternAgreement([]bool{true, true, false, false}, 2), 1e-9)
+	assert.InDelta(t, -1, patternAgreement([]bool{true, false, false, true}, 2), 1e-9)
+	assert.InDelta(t, 1, patternAgreement([]bool{false, true, false, false}, 1), 1e-9)
+}
+
+func TestPollResult_Describe(t *testing.T) {
+	result := deliberateTallies(t, []uint64{1, 2, 3}, []uint64{3, 2, 1})
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), `"statistics"`, "Statistics should be optional")
+
+	result.Describe()
+	assert.InDelta(t, 4.0/3, result.Proposals[0].Statistics.Mean, 1e-9)
+	assert.Equal(t, result.Proposals[0].Statistics, result.ProposalsSorted[0].Statistics)
+	data, err = json.Marshal(result)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"statistics":{"mean":`)
+}
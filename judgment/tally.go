@@ -22,6 +22,18 @@ func (pollTally *PollTally) GuessAmountOfJudges() uint64 {
 	return pollTally.AmountOfJudges
 }
 
+// Copy a PollTally (deeply)
+func (pollTally *PollTally) Copy() (_ *PollTally) {
+	proposalsTallies := make([]*ProposalTally, 0, len(pollTally.Proposals))
+	for _, proposalTally := range pollTally.Proposals {
+		proposalsTallies = append(proposalsTallies, proposalTally.Copy())
+	}
+	return &PollTally{
+		AmountOfJudges: pollTally.AmountOfJudges,
+		Proposals:      proposalsTallies,
+	}
+}
+
 // BalanceWithStaticDefault makes sure all proposals received the same amount of judgments,
 // by filling the gaps with judgments of the specified default grade.
 // This method mutates the PollTally
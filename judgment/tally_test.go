@@ -296,3 +296,19 @@ func TestProposalTally_BalanceWithMedianDefaultFailureAmountTooLow(t *testing.T)
 	err := pollTally.BalanceWithMedianDefault()
 	assert.Error(t, err, "Filling should fail")
 }
+
+func TestPollTally_Copy(t *testing.T) {
+	poll := &PollTally{
+		AmountOfJudges: 3,
+		Proposals: []*ProposalTally{
+			{Tally: []uint64{1, 1, 1}},
+			{Tally: []uint64{0, 2, 1}},
+		},
+	}
+	copied := poll.Copy()
+	assert.Equal(t, poll, copied)
+	copied.Proposals[0].Tally[0] = 42
+	copied.AmountOfJudges = 44
+	assert.Equal(t, uint64(1), poll.Proposals[0].Tally[0], "Copy should be deep")
+	assert.Equal(t, uint64(3), poll.AmountOfJudges)
+}
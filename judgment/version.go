@@ -0,0 +1,5 @@
+package judgment
+
+// Version of this library, recorded in election bundles so that third parties can recompute results with it.
+// We follow semantic versioning ; bump it with each release.
+const Version = "0.1.0"
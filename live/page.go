@@ -0,0 +1,147 @@
+package live
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"html/template"
+	"net/http"
+)
+
+// pageConfig is handed to the script of the page as JSON.
+type pageConfig struct {
+	StreamURL string          `json:"streamUrl"`
+	Proposals []string        `json:"proposals"`
+	Grades    []pageGradeView `json:"grades"`
+}
+
+type pageGradeView struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type pageView struct {
+	Title  string
+	Config pageConfig
+}
+
+// NewPageHandler serves a minimal HTML page consuming the stream at streamURL, see NewStreamHandler,
+// and drawing the merit profiles of the proposals as they update.
+// Names, grades, colors and title come from options, which may be nil.
+func NewPageHandler(poll *Poll, streamURL string, options *judgment.ReportOptions) http.Handler {
+	pollTally := poll.Latest().Tally
+	amountOfGrades := 0
+	if len(pollTally.Proposals) > 0 {
+		amountOfGrades = len(pollTally.Proposals[0].Tally)
+	}
+
+	view := &pageView{
+		Title: "Majority Judgment Live Results",
+		Config: pageConfig{
+			StreamURL: streamURL,
+			Proposals: make([]string, 0, len(pollTally.Proposals)),
+			Grades:    make([]pageGradeView, 0, amountOfGrades),
+		},
+	}
+	if nil != options && "" != options.Title {
+		view.Title = options.Title
+	}
+	for proposalIndex := range pollTally.Proposals {
+		view.Config.Proposals = append(view.Config.Proposals, options.ProposalName(proposalIndex))
+	}
+	palette := options.GradesPalette(amountOfGrades)
+	for grade := 0; grade < amountOfGrades; grade++ {
+		view.Config.Grades = append(view.Config.Grades, pageGradeView{
+			Name:  options.GradeName(uint8(grade)),
+			Color: judgment.DumpColorHexString(palette[grade], "#", false),
+		})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if http.MethodGet != r.Method && http.MethodHead != r.Method {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "use GET on this page", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = pageTemplate.Execute(w, view)
+	})
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: sans-serif; margin: 2em; color: #222; }
+  table { border-collapse: collapse; width: 100%; }
+  td { padding: 0.3em 0.5em; }
+  td.rank { text-align: right; font-weight: bold; width: 3em; }
+  td.name { white-space: nowrap; width: 12em; }
+  .profile { position: relative; display: flex; height: 1.6em; background: #eee; }
+  .profile div { height: 100%; transition: width 0.4s; }
+  .profile .median { position: absolute; left: 50%; top: -0.2em; bottom: -0.2em; border-left: 2px dashed #222; }
+  .legend span { display: inline-block; margin-right: 1em; }
+  .legend i { display: inline-block; width: 1em; height: 1em; margin-right: 0.3em; vertical-align: middle; }
+  #status { color: #888; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="legend" id="legend"></p>
+<table><tbody id="rows"></tbody></table>
+<p id="status">Connecting…</p>
+<script>
+"use strict";
+const config = {{.Config}};
+const rows = document.getElementById("rows");
+const status = document.getElementById("status");
+
+for (const grade of config.grades) {
+  const span = document.createElement("span");
+  const swatch = document.createElement("i");
+  swatch.style.background = grade.color;
+  span.append(swatch, grade.name);
+  document.getElementById("legend").append(span);
+}
+
+function render(update) {
+  const sorted = update.result.proposalsSorted || [];
+  rows.replaceChildren(...sorted.map((proposal) => {
+    const row = document.createElement("tr");
+    const rank = document.createElement("td");
+    rank.className = "rank";
+    rank.textContent = proposal.rank;
+    const name = document.createElement("td");
+    name.className = "name";
+    name.textContent = config.proposals[proposal.index] || ("#" + proposal.index);
+    const profile = document.createElement("div");
+    profile.className = "profile";
+    const tally = proposal.tally.tally;
+    const total = tally.reduce((sum, amount) => sum + amount, 0);
+    // Best grades first, so that the median sits under the dashed line
+    for (let grade = tally.length - 1; grade >= 0; grade--) {
+      const segment = document.createElement("div");
+      segment.style.width = (total ? 100 * tally[grade] / total : 0) + "%";
+      segment.style.background = (config.grades[grade] || {}).color || "#888";
+      segment.title = ((config.grades[grade] || {}).name || grade) + ": " + tally[grade];
+      profile.append(segment);
+    }
+    const median = document.createElement("div");
+    median.className = "median";
+    profile.append(median);
+    const bar = document.createElement("td");
+    bar.append(profile);
+    row.append(rank, name, bar);
+    return row;
+  }));
+  status.textContent = "Update #" + update.version;
+}
+
+const source = new EventSource(config.streamUrl);
+source.addEventListener("result", (event) => render(JSON.parse(event.data)));
+source.onerror = () => { status.textContent = "Reconnecting…"; };
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,45 @@
+package live
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPageHandler(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	options := &judgment.ReportOptions{
+		Title:         "Lunch </script>",
+		ProposalNames: []string{"Pizza", "<b>Sushi</b>"},
+		GradeNames:    []string{"bad", "fair", "good"},
+	}
+	handler := NewPageHandler(poll, "/stream", options)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	page := recorder.Body.String()
+	assert.Contains(t, page, "<title>Lunch &lt;/script&gt;</title>")
+	assert.Contains(t, page, `"streamUrl":"/stream"`)
+	assert.Contains(t, page, `"name":"fair"`)
+	assert.Contains(t, page, `"color":"#`)
+	assert.NotContains(t, page, "<b>Sushi</b>", "Names should be escaped")
+	assert.Contains(t, page, "new EventSource(config.streamUrl)")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestPageHandler_Defaults(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	NewPageHandler(poll, "/stream", nil).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, recorder.Body.String(), "Majority Judgment Live Results")
+	assert.Contains(t, recorder.Body.String(), `"Proposal B"`)
+}
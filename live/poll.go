@@ -0,0 +1,174 @@
+// Package live deliberates polls again as judgments come in, and tells subscribers about each new result.
+//
+//	poll, err := live.NewPoll(pollTally, &judgment.BalancingStrategy{Kind: judgment.BalancingStatic})
+//	updates, unsubscribe := poll.Subscribe()
+//	defer unsubscribe()
+//	err = poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 4})
+//	update := <-updates
+package live
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/mieuxvoter/majority-judgment-library-go/store"
+	"sync"
+)
+
+// ErrClosed is returned when adding judgments to a closed poll.
+var ErrClosed = errors.New("poll is closed")
+
+// Update is a new result of a poll.
+type Update struct {
+	Version uint64               `json:"version"` // increases with each update, starting at 1 with the initial result
+	Tally   *judgment.PollTally  `json:"tally"`   // as counted, before balancing
+	Result  *judgment.PollResult `json:"result"`
+}
+
+// Poll holds a tally, and its result according to the balancing strategy and the MajorityJudgment deliberator.
+// It is safe for concurrent use.
+type Poll struct {
+	mutex       sync.Mutex
+	balancing   *judgment.BalancingStrategy
+	store       store.Store // may be nil
+	id          string
+	tally       *judgment.PollTally
+	latest      Update
+	subscribers map[int]chan Update
+	nextID      int
+	closed      bool
+}
+
+// NewPoll starts a live poll from a copy of the tally.
+// Since judgments trickle in, you usually want a balancing strategy.
+func NewPoll(pollTally *judgment.PollTally, balancing *judgment.BalancingStrategy) (*Poll, error) {
+	poll := &Poll{
+		balancing:   balancing,
+		tally:       pollTally.Copy(),
+		subscribers: map[int]chan Update{},
+	}
+	result, err := deliberate(poll.tally, balancing)
+	if nil != err {
+		return nil, err
+	}
+	poll.publish(result)
+	return poll, nil
+}
+
+// NewStoredPoll starts a live poll from the tally of poll id in the store,
+// and appends judgments to the store before counting them.
+func NewStoredPoll(s store.Store, id string, balancing *judgment.BalancingStrategy) (*Poll, error) {
+	pollTally, err := s.LoadTally(id)
+	if nil != err {
+		return nil, err
+	}
+	poll, err := NewPoll(pollTally, balancing)
+	if nil != err {
+		return nil, err
+	}
+	poll.store = s
+	poll.id = id
+	return poll, nil
+}
+
+// AddJudgments counts the judgments, all or none of them, deliberates, and publishes the new result.
+// With a store, the judgments are appended to it once we know they can be deliberated.
+func (poll *Poll) AddJudgments(judgments ...store.Judgment) error {
+	poll.mutex.Lock()
+	defer poll.mutex.Unlock()
+	if poll.closed {
+		return ErrClosed
+	}
+
+	pollTally := poll.tally.Copy()
+	for judgmentIndex, j := range judgments {
+		if j.Proposal < 0 || j.Proposal >= len(pollTally.Proposals) ||
+			int(j.Grade) >= len(pollTally.Proposals[j.Proposal].Tally) {
+			return fmt.Errorf("%w: judgment #%d", store.ErrInvalidJudgment, judgmentIndex)
+		}
+		pollTally.Proposals[j.Proposal].Tally[j.Grade]++
+	}
+	result, err := deliberate(pollTally, poll.balancing)
+	if nil != err {
+		return err
+	}
+	if nil != poll.store {
+		if err := poll.store.AppendJudgments(poll.id, judgments...); nil != err {
+			return err
+		}
+	}
+
+	poll.tally = pollTally
+	poll.publish(result)
+	return nil
+}
+
+// Latest returns the latest update.
+func (poll *Poll) Latest() Update {
+	poll.mutex.Lock()
+	defer poll.mutex.Unlock()
+	return poll.latest
+}
+
+// Subscribe returns a channel receiving the latest update right away, and then each new update.
+// Slow subscribers skip intermediate updates and only get the latest one.
+// Call unsubscribe to release the subscription ; the channel is closed.
+func (poll *Poll) Subscribe() (updates <-chan Update, unsubscribe func()) {
+	poll.mutex.Lock()
+	defer poll.mutex.Unlock()
+	channel := make(chan Update, 1)
+	if poll.closed {
+		close(channel)
+		return channel, func() {}
+	}
+	channel <- poll.latest
+	id := poll.nextID
+	poll.nextID++
+	poll.subscribers[id] = channel
+
+	once := sync.Once{}
+	return channel, func() {
+		once.Do(func() {
+			poll.mutex.Lock()
+			defer poll.mutex.Unlock()
+			if subscriber, exists := poll.subscribers[id]; exists {
+				delete(poll.subscribers, id)
+				close(subscriber)
+			}
+		})
+	}
+}
+
+// Close closes the channels of all the subscribers, and refuses further judgments.
+func (poll *Poll) Close() {
+	poll.mutex.Lock()
+	defer poll.mutex.Unlock()
+	poll.closed = true
+	for id, subscriber := range poll.subscribers {
+		delete(poll.subscribers, id)
+		close(subscriber)
+	}
+}
+
+// publish must be called with the mutex held.
+func (poll *Poll) publish(result *judgment.PollResult) {
+	poll.latest = Update{Version: poll.latest.Version + 1, Tally: poll.tally.Copy(), Result: result}
+	for _, subscriber := range poll.subscribers {
+		// Latest wins: drop the pending update, if any, which nobody read yet.
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- poll.latest
+	}
+}
+
+// deliberate balances a copy of the tally, so that the original keeps counting actual judgments only.
+func deliberate(pollTally *judgment.PollTally, balancing *judgment.BalancingStrategy) (*judgment.PollResult, error) {
+	balanced := pollTally.Copy()
+	if err := balanced.Balance(balancing); nil != err {
+		return nil, err
+	}
+	deliberator := &judgment.MajorityJudgment{}
+	return deliberator.Deliberate(balanced)
+}
@@ -0,0 +1,111 @@
+package live
+
+import (
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/mieuxvoter/majority-judgment-library-go/store"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var staticBalancing = &judgment.BalancingStrategy{Kind: judgment.BalancingStatic}
+
+func newEmptyTally(amountOfProposals int, amountOfGrades int) *judgment.PollTally {
+	pollTally := &judgment.PollTally{}
+	for i := 0; i < amountOfProposals; i++ {
+		pollTally.Proposals = append(pollTally.Proposals, &judgment.ProposalTally{Tally: make([]uint64, amountOfGrades)})
+	}
+	return pollTally
+}
+
+func TestPoll_AddJudgments(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err, "Starting the poll should succeed")
+	assert.Equal(t, uint64(1), poll.Latest().Version)
+
+	updates, unsubscribe := poll.Subscribe()
+	defer unsubscribe()
+	assert.Equal(t, uint64(1), (<-updates).Version, "Subscribers should get the latest update right away")
+
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 2}))
+	update := <-updates
+	assert.Equal(t, uint64(2), update.Version)
+	assert.Equal(t, 1, update.Result.ProposalsSorted[0].Index)
+	assert.Equal(t, []uint64{0, 0, 0}, update.Tally.Proposals[0].Tally, "Updates should hold the tally before balancing")
+
+	err = poll.AddJudgments(store.Judgment{Proposal: 0, Grade: 2}, store.Judgment{Proposal: 2, Grade: 0})
+	assert.True(t, errors.Is(err, store.ErrInvalidJudgment))
+	err = poll.AddJudgments(store.Judgment{Proposal: 0, Grade: 3})
+	assert.True(t, errors.Is(err, store.ErrInvalidJudgment))
+	assert.Equal(t, uint64(2), poll.Latest().Version, "Refused judgments should not publish anything")
+	assert.Equal(t, []uint64{0, 0, 0}, poll.Latest().Tally.Proposals[0].Tally)
+}
+
+func TestPoll_SlowSubscriber(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(1, 2), staticBalancing)
+	assert.NoError(t, err)
+	updates, unsubscribe := poll.Subscribe()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, poll.AddJudgments(store.Judgment{Grade: 1}))
+	}
+	update := <-updates
+	assert.Equal(t, uint64(6), update.Version, "Slow subscribers should only get the latest update")
+	unsubscribe()
+	unsubscribe()
+	_, open := <-updates
+	assert.False(t, open, "Unsubscribing should close the channel")
+}
+
+func TestPoll_Close(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(1, 2), nil)
+	assert.NoError(t, err)
+	updates, unsubscribe := poll.Subscribe()
+	poll.Close()
+	<-updates
+	_, open := <-updates
+	assert.False(t, open)
+	unsubscribe()
+	assert.True(t, errors.Is(poll.AddJudgments(store.Judgment{}), ErrClosed))
+
+	late, _ := poll.Subscribe()
+	_, open = <-late
+	assert.False(t, open, "Subscribing to a closed poll should give a closed channel")
+}
+
+func TestPoll_DeliberationFailure(t *testing.T) {
+	_, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+
+	unbalanced := newEmptyTally(2, 3)
+	unbalanced.Proposals[0].Tally[1] = 1
+	_, err = NewPoll(unbalanced, nil)
+	assert.True(t, errors.Is(err, judgment.ErrUnbalancedTally))
+
+	poll, err := NewPoll(newEmptyTally(2, 3), nil)
+	assert.NoError(t, err)
+	err = poll.AddJudgments(store.Judgment{Proposal: 0, Grade: 1})
+	assert.True(t, errors.Is(err, judgment.ErrUnbalancedTally))
+	assert.Equal(t, []uint64{0, 0, 0}, poll.Latest().Tally.Proposals[0].Tally)
+}
+
+func TestNewStoredPoll(t *testing.T) {
+	s := store.NewMemoryStore()
+	assert.NoError(t, s.CreatePoll("lunch", 2, 3))
+	assert.NoError(t, s.AppendJudgments("lunch", store.Judgment{Proposal: 0, Grade: 2}))
+
+	poll, err := NewStoredPoll(s, "lunch", staticBalancing)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0, 0, 1}, poll.Latest().Tally.Proposals[0].Tally, "The tally should be loaded from the store")
+
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 1}))
+	pollTally, err := s.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0, 1, 0}, pollTally.Proposals[1].Tally, "Judgments should be appended to the store")
+
+	assert.NoError(t, s.Close())
+	assert.True(t, errors.Is(poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 1}), store.ErrClosed))
+	assert.Equal(t, []uint64{0, 1, 0}, poll.Latest().Tally.Proposals[1].Tally, "Judgments refused by the store should not count")
+
+	_, err = NewStoredPoll(s, "dinner", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults of the StreamOptions
+const (
+	defaultMinInterval       = 250 * time.Millisecond
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultRetry             = 3 * time.Second
+)
+
+// StreamOptions tunes the Server-Sent Events stream of a poll.
+type StreamOptions struct {
+	MinInterval       time.Duration // throttles updates: intermediate ones are skipped ; defaults to 250ms
+	HeartbeatInterval time.Duration // comments keeping idle connections open ; defaults to 15s
+	Retry             time.Duration // reconnection delay advised to clients ; defaults to 3s
+}
+
+func (options *StreamOptions) withDefaults() StreamOptions {
+	filled := StreamOptions{}
+	if nil != options {
+		filled = *options
+	}
+	if filled.MinInterval <= 0 {
+		filled.MinInterval = defaultMinInterval
+	}
+	if filled.HeartbeatInterval <= 0 {
+		filled.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if filled.Retry <= 0 {
+		filled.Retry = defaultRetry
+	}
+	return filled
+}
+
+// epoch tells apart the event IDs of this process from those of its previous runs, since versions restart at 1.
+var epoch = strconv.FormatInt(time.Now().UnixNano(), 36)
+
+// NewStreamHandler streams the updates of the poll as Server-Sent Events named "result",
+// whose IDs are the epoch of the process, a dash and the version of the update, and whose data are the updates as JSON.
+//
+// The latest update is sent on connection, unless the client resumes with a Last-Event-ID header
+// (or lastEventId query parameter) matching it ; since each update holds the whole result,
+// resuming only needs the latest one.  IDs from another epoch, like before a restart, are ignored.
+// options may be nil.
+func NewStreamHandler(poll *Poll, options *StreamOptions) http.Handler {
+	filled := options.withDefaults()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		streamUpdates(w, r, poll, filled)
+	})
+}
+
+func streamUpdates(w http.ResponseWriter, r *http.Request, poll *Poll, options StreamOptions) {
+	if http.MethodGet != r.Method {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "use GET on this endpoint", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastVersion := lastEventID(r)
+	updates, unsubscribe := poll.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // for nginx
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", options.Retry/time.Millisecond); nil != err {
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(options.HeartbeatInterval)
+	defer heartbeat.Stop()
+	var lastSent time.Time
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); nil != err {
+				return
+			}
+			flusher.Flush()
+		case update, open := <-updates:
+			if !open {
+				return
+			}
+			if wait := options.MinInterval - time.Since(lastSent); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+				// Skip the updates which came in while we waited.
+				select {
+				case newer, open := <-updates:
+					if !open {
+						return
+					}
+					update = newer
+				default:
+				}
+			}
+			if update.Version <= lastVersion {
+				continue
+			}
+			if err := writeEvent(w, update); nil != err {
+				return
+			}
+			flusher.Flush()
+			lastVersion = update.Version
+			lastSent = time.Now()
+		}
+	}
+}
+
+// eventID identifies the update of the given version within this process.
+func eventID(version uint64) string {
+	return fmt.Sprintf("%s-%d", epoch, version)
+}
+
+// lastEventID reads the version the client already has, 0 if none or if it comes from another epoch.
+func lastEventID(r *http.Request) uint64 {
+	id := r.Header.Get("Last-Event-ID")
+	if "" == id {
+		id = r.URL.Query().Get("lastEventId")
+	}
+	prefix := epoch + "-"
+	id = strings.TrimSpace(id)
+	if !strings.HasPrefix(id, prefix) {
+		return 0
+	}
+	version, err := strconv.ParseUint(id[len(prefix):], 10, 64)
+	if nil != err {
+		return 0
+	}
+	return version
+}
+
+func writeEvent(w http.ResponseWriter, update Update) error {
+	data, err := json.Marshal(update)
+	if nil != err {
+		return err
+	}
+	// JSON from encoding/json holds no raw newlines, so a single data line suffices.
+	_, err = fmt.Fprintf(w, "id: %s\nevent: result\ndata: %s\n\n", eventID(update.Version), data)
+	return err
+}
@@ -0,0 +1,197 @@
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/mieuxvoter/majority-judgment-library-go/store"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent is an event as read by a client
+type sseEvent struct {
+	id, name, data, comment string
+}
+
+// readEvents connects to the stream, and sends its events on the returned channel until cancel is called.
+func readEvents(t *testing.T, url string, lastEventID string) (<-chan sseEvent, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	request = request.WithContext(ctx)
+	if "" != lastEventID {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+	response, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err, "Connecting to the stream should succeed")
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 100)
+	go func() {
+		defer close(events)
+		defer response.Body.Close()
+		scanner := bufio.NewScanner(response.Body)
+		scanner.Buffer(make([]byte, 1<<16), 1<<20)
+		event := sseEvent{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case "" == line:
+				events <- event
+				event = sseEvent{}
+			case strings.HasPrefix(line, ":"):
+				event.comment = strings.TrimSpace(line[1:])
+			case strings.HasPrefix(line, "id: "):
+				event.id = line[4:]
+			case strings.HasPrefix(line, "event: "):
+				event.name = line[7:]
+			case strings.HasPrefix(line, "data: "):
+				event.data = line[6:]
+			}
+		}
+	}()
+	return events, cancel
+}
+
+// nextResult skips retry and heartbeat blocks.
+func nextResult(t *testing.T, events <-chan sseEvent) sseEvent {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if "result" == event.name {
+				return event
+			}
+		case <-timeout:
+			t.Fatal("no result event")
+		}
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	server := httptest.NewServer(NewStreamHandler(poll, &StreamOptions{MinInterval: time.Millisecond}))
+	defer server.Close()
+
+	events, cancel := readEvents(t, server.URL, "")
+	defer cancel()
+	event := nextResult(t, events)
+	assert.Equal(t, eventID(1), event.id, "The latest update should be sent on connection")
+
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 2}))
+	event = nextResult(t, events)
+	assert.Equal(t, eventID(2), event.id)
+	update := Update{}
+	assert.NoError(t, json.Unmarshal([]byte(event.data), &update))
+	assert.Equal(t, uint64(2), update.Version)
+	assert.Equal(t, 1, update.Result.ProposalsSorted[0].Index)
+}
+
+func TestStreamHandler_Throttling(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	server := httptest.NewServer(NewStreamHandler(poll, &StreamOptions{MinInterval: 200 * time.Millisecond}))
+	defer server.Close()
+
+	events, cancel := readEvents(t, server.URL, "")
+	defer cancel()
+	assert.Equal(t, eventID(1), nextResult(t, events).id)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: i % 2, Grade: 1}))
+	}
+	assert.Equal(t, eventID(11), nextResult(t, events).id, "Updates within the interval should be skipped")
+}
+
+func TestStreamHandler_Resume(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 0, Grade: 1}))
+	server := httptest.NewServer(NewStreamHandler(poll, &StreamOptions{MinInterval: time.Millisecond}))
+	defer server.Close()
+
+	events, cancel := readEvents(t, server.URL, eventID(2))
+	defer cancel()
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 1}))
+	assert.Equal(t, eventID(3), nextResult(t, events).id, "Known updates should not be sent again")
+
+	stale, cancelStale := readEvents(t, server.URL, eventID(1))
+	defer cancelStale()
+	assert.Equal(t, eventID(3), nextResult(t, stale).id, "Clients behind should get the latest update")
+
+	query, cancelQuery := readEvents(t, server.URL+"?lastEventId="+eventID(3), "")
+	defer cancelQuery()
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 2}))
+	assert.Equal(t, eventID(4), nextResult(t, query).id)
+}
+
+func TestStreamHandler_ResumeAfterRestart(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(2, 3), staticBalancing)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 0, Grade: 1}))
+	}
+	server := httptest.NewServer(NewStreamHandler(poll, &StreamOptions{MinInterval: time.Millisecond}))
+	defer server.Close()
+
+	// IDs of a previous process, behind, level and ahead of the current version, and a bare version.
+	for _, id := range []string{"0previous-2", "0previous-4", "0previous-42", "4"} {
+		events, cancel := readEvents(t, server.URL+"?lastEventId="+id, "")
+		assert.Equal(t, eventID(4), nextResult(t, events).id, "IDs from another epoch should be ignored: %s", id)
+		cancel()
+	}
+}
+
+func TestStreamHandler_Heartbeat(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(1, 2), staticBalancing)
+	assert.NoError(t, err)
+	server := httptest.NewServer(NewStreamHandler(poll, &StreamOptions{HeartbeatInterval: 5 * time.Millisecond}))
+	defer server.Close()
+
+	events, cancel := readEvents(t, server.URL, "")
+	defer cancel()
+	for event := range events {
+		if "heartbeat" == event.comment {
+			return
+		}
+	}
+	t.Error("no heartbeat")
+}
+
+func TestStreamHandler_ClosedPoll(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(1, 2), staticBalancing)
+	assert.NoError(t, err)
+	server := httptest.NewServer(NewStreamHandler(poll, nil))
+	defer server.Close()
+
+	events, cancel := readEvents(t, server.URL, "")
+	defer cancel()
+	nextResult(t, events)
+	poll.Close()
+	for range events {
+	}
+}
+
+// unflushableWriter hides the http.Flusher of the recorder.
+type unflushableWriter struct {
+	http.ResponseWriter
+}
+
+func TestStreamHandler_Failures(t *testing.T) {
+	poll, err := NewPoll(newEmptyTally(1, 2), staticBalancing)
+	assert.NoError(t, err)
+	handler := NewStreamHandler(poll, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(&unflushableWriter{recorder}, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -0,0 +1,29 @@
+// Command mj-grpc-server serves the JudgmentService over gRPC.
+//
+//	mj-grpc-server -addr :50051
+package main
+
+import (
+	"flag"
+	"github.com/mieuxvoter/majority-judgment-library-go/mjgrpc"
+	pb "github.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpb"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+)
+
+func main() {
+	addr := flag.String("addr", ":50051", "address to listen on")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *addr)
+	if nil != err {
+		log.Fatalf("mj-grpc-server: %v", err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterJudgmentServiceServer(server, mjgrpc.NewServer())
+
+	log.Printf("mj-grpc-server listening on %s", listener.Addr())
+	log.Fatal(server.Serve(listener))
+}
@@ -0,0 +1,204 @@
+// Package mjgrpc exposes Majority Judgment deliberation as a gRPC service.
+//
+// The protocol buffers definitions live in proto/, and the generated stubs in judgmentpb/.
+// Regenerate them with `buf generate` from this directory.
+package mjgrpc
+
+import (
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	pb "github.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpb"
+	"math"
+)
+
+// ProposalTallyToProto converts a judgment.ProposalTally into its protocol buffers message.
+func ProposalTallyToProto(proposalTally *judgment.ProposalTally) *pb.ProposalTally {
+	if nil == proposalTally {
+		return nil
+	}
+	return &pb.ProposalTally{Tally: append([]uint64{}, proposalTally.Tally...)}
+}
+
+// ProposalTallyFromProto converts a protocol buffers message into a judgment.ProposalTally.
+func ProposalTallyFromProto(message *pb.ProposalTally) *judgment.ProposalTally {
+	if nil == message {
+		return &judgment.ProposalTally{Tally: []uint64{}}
+	}
+	return &judgment.ProposalTally{Tally: append([]uint64{}, message.GetTally()...)}
+}
+
+// PollTallyToProto converts a judgment.PollTally into its protocol buffers message.
+func PollTallyToProto(pollTally *judgment.PollTally) *pb.PollTally {
+	if nil == pollTally {
+		return nil
+	}
+	message := &pb.PollTally{
+		AmountOfJudges: pollTally.AmountOfJudges,
+		Proposals:      make([]*pb.ProposalTally, 0, len(pollTally.Proposals)),
+	}
+	for _, proposalTally := range pollTally.Proposals {
+		message.Proposals = append(message.Proposals, ProposalTallyToProto(proposalTally))
+	}
+	return message
+}
+
+// PollTallyFromProto converts a protocol buffers message into a judgment.PollTally.
+func PollTallyFromProto(message *pb.PollTally) *judgment.PollTally {
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: message.GetAmountOfJudges(),
+		Proposals:      make([]*judgment.ProposalTally, 0, len(message.GetProposals())),
+	}
+	for _, proposalMessage := range message.GetProposals() {
+		pollTally.Proposals = append(pollTally.Proposals, ProposalTallyFromProto(proposalMessage))
+	}
+	return pollTally
+}
+
+// ProposalAnalysisToProto converts a judgment.ProposalAnalysis into its protocol buffers message.
+func ProposalAnalysisToProto(analysis *judgment.ProposalAnalysis) *pb.ProposalAnalysis {
+	if nil == analysis {
+		return nil
+	}
+	return &pb.ProposalAnalysis{
+		TotalSize:              analysis.TotalSize,
+		MedianGrade:            uint32(analysis.MedianGrade),
+		MedianGroupSize:        analysis.MedianGroupSize,
+		SecondMedianGrade:      uint32(analysis.SecondMedianGrade),
+		SecondGroupSize:        analysis.SecondGroupSize,
+		SecondGroupSign:        int32(analysis.SecondGroupSign),
+		AdhesionGroupGrade:     uint32(analysis.AdhesionGroupGrade),
+		AdhesionGroupSize:      analysis.AdhesionGroupSize,
+		ContestationGroupGrade: uint32(analysis.ContestationGroupGrade),
+		ContestationGroupSize:  analysis.ContestationGroupSize,
+	}
+}
+
+// ProposalAnalysisFromProto converts a protocol buffers message into a judgment.ProposalAnalysis.
+// It fails when grades do not fit in a uint8.
+func ProposalAnalysisFromProto(message *pb.ProposalAnalysis) (*judgment.ProposalAnalysis, error) {
+	if nil == message {
+		return nil, nil
+	}
+	grades := []uint32{
+		message.GetMedianGrade(),
+		message.GetSecondMedianGrade(),
+		message.GetAdhesionGroupGrade(),
+		message.GetContestationGroupGrade(),
+	}
+	for _, grade := range grades {
+		if grade > math.MaxUint8 {
+			return nil, fmt.Errorf("ProposalAnalysisFromProto() grade %d is too high", grade)
+		}
+	}
+	return &judgment.ProposalAnalysis{
+		TotalSize:              message.GetTotalSize(),
+		MedianGrade:            uint8(message.GetMedianGrade()),
+		MedianGroupSize:        message.GetMedianGroupSize(),
+		SecondMedianGrade:      uint8(message.GetSecondMedianGrade()),
+		SecondGroupSize:        message.GetSecondGroupSize(),
+		SecondGroupSign:        int(message.GetSecondGroupSign()),
+		AdhesionGroupGrade:     uint8(message.GetAdhesionGroupGrade()),
+		AdhesionGroupSize:      message.GetAdhesionGroupSize(),
+		ContestationGroupGrade: uint8(message.GetContestationGroupGrade()),
+		ContestationGroupSize:  message.GetContestationGroupSize(),
+	}, nil
+}
+
+// PollResultToProto converts a judgment.PollResult into its protocol buffers message.
+// ProposalsSorted is sent as indices into Proposals.
+func PollResultToProto(result *judgment.PollResult) *pb.PollResult {
+	if nil == result {
+		return nil
+	}
+	message := &pb.PollResult{
+		Proposals:     make([]*pb.ProposalResult, 0, len(result.Proposals)),
+		SortedIndices: make([]uint32, 0, len(result.ProposalsSorted)),
+	}
+	for _, proposalResult := range result.Proposals {
+		message.Proposals = append(message.Proposals, &pb.ProposalResult{
+			Index:    uint32(proposalResult.Index),
+			Rank:     uint32(proposalResult.Rank),
+			Score:    proposalResult.Score,
+			Analysis: ProposalAnalysisToProto(proposalResult.Analysis),
+			Tally:    ProposalTallyToProto(proposalResult.Tally),
+		})
+	}
+	for _, proposalResult := range result.ProposalsSorted {
+		message.SortedIndices = append(message.SortedIndices, uint32(proposalResult.Index))
+	}
+	return message
+}
+
+// PollResultFromProto converts a protocol buffers message into a judgment.PollResult.
+// Like with Deliberate, ProposalsSorted shares its pointers with Proposals.
+func PollResultFromProto(message *pb.PollResult) (*judgment.PollResult, error) {
+	result := &judgment.PollResult{
+		Proposals:       make(judgment.ProposalsResults, 0, len(message.GetProposals())),
+		ProposalsSorted: make(judgment.ProposalsResults, 0, len(message.GetSortedIndices())),
+	}
+	for _, proposalMessage := range message.GetProposals() {
+		analysis, err := ProposalAnalysisFromProto(proposalMessage.GetAnalysis())
+		if nil != err {
+			return nil, err
+		}
+		var proposalTally *judgment.ProposalTally
+		if nil != proposalMessage.GetTally() {
+			proposalTally = ProposalTallyFromProto(proposalMessage.GetTally())
+		}
+		result.Proposals = append(result.Proposals, &judgment.ProposalResult{
+			Index:    int(proposalMessage.GetIndex()),
+			Rank:     int(proposalMessage.GetRank()),
+			Score:    proposalMessage.GetScore(),
+			Analysis: analysis,
+			Tally:    proposalTally,
+		})
+	}
+	for _, index := range message.GetSortedIndices() {
+		if int(index) >= len(result.Proposals) {
+			return nil, fmt.Errorf("PollResultFromProto() sorted index %d is out of range", index)
+		}
+		result.ProposalsSorted = append(result.ProposalsSorted, result.Proposals[index])
+	}
+	return result, nil
+}
+
+// BalancingStrategyFromProto converts a protocol buffers message into a judgment.BalancingStrategy.
+func BalancingStrategyFromProto(message *pb.BalancingStrategy) (*judgment.BalancingStrategy, error) {
+	if nil == message {
+		return nil, nil
+	}
+	if message.GetDefaultGrade() > math.MaxUint8 {
+		return nil, fmt.Errorf("BalancingStrategyFromProto() default grade %d is too high", message.GetDefaultGrade())
+	}
+	strategy := &judgment.BalancingStrategy{DefaultGrade: uint8(message.GetDefaultGrade())}
+	switch message.GetKind() {
+	case pb.BalancingStrategy_KIND_UNSPECIFIED, pb.BalancingStrategy_KIND_NONE:
+		strategy.Kind = judgment.BalancingNone
+	case pb.BalancingStrategy_KIND_STATIC:
+		strategy.Kind = judgment.BalancingStatic
+	case pb.BalancingStrategy_KIND_MEDIAN:
+		strategy.Kind = judgment.BalancingMedian
+	default:
+		return nil, fmt.Errorf("BalancingStrategyFromProto() unknown kind %d", message.GetKind())
+	}
+	return strategy, nil
+}
+
+// BalancingStrategyToProto converts a judgment.BalancingStrategy into its protocol buffers message.
+func BalancingStrategyToProto(strategy *judgment.BalancingStrategy) (*pb.BalancingStrategy, error) {
+	if nil == strategy {
+		return nil, nil
+	}
+	message := &pb.BalancingStrategy{DefaultGrade: uint32(strategy.DefaultGrade)}
+	switch strategy.Kind {
+	case judgment.BalancingNone, "":
+		message.Kind = pb.BalancingStrategy_KIND_NONE
+	case judgment.BalancingStatic:
+		message.Kind = pb.BalancingStrategy_KIND_STATIC
+	case judgment.BalancingMedian:
+		message.Kind = pb.BalancingStrategy_KIND_MEDIAN
+	default:
+		return nil, fmt.Errorf("BalancingStrategyToProto() unknown kind %q", strategy.Kind)
+	}
+	return message, nil
+}
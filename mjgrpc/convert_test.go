@@ -0,0 +1,73 @@
+package mjgrpc
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	pb "github.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpb"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPollTally_RoundTrip(t *testing.T) {
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: 10,
+		Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+			{Tally: []uint64{2, 1, 1, 1, 5}},
+		},
+	}
+	assert.Equal(t, pollTally, PollTallyFromProto(PollTallyToProto(pollTally)))
+}
+
+func TestPollResult_RoundTrip(t *testing.T) {
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: 10,
+		Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+			{Tally: []uint64{2, 1, 1, 1, 5}},
+			{Tally: []uint64{2, 1, 5, 0, 2}},
+		},
+	}
+	result, err := (&judgment.MajorityJudgment{}).Deliberate(pollTally)
+	assert.NoError(t, err)
+
+	converted, err := PollResultFromProto(PollResultToProto(result))
+	assert.NoError(t, err, "Conversion should succeed")
+	assert.Equal(t, result, converted)
+	assert.True(t, converted.ProposalsSorted[0] == converted.Proposals[1], "Sorted results should share pointers")
+}
+
+func TestPollResultFromProto_Failures(t *testing.T) {
+	_, err := PollResultFromProto(&pb.PollResult{SortedIndices: []uint32{0}})
+	assert.Error(t, err, "Sorted indices should be in range")
+
+	_, err = PollResultFromProto(&pb.PollResult{
+		Proposals: []*pb.ProposalResult{{Analysis: &pb.ProposalAnalysis{MedianGrade: 256}}},
+	})
+	assert.Error(t, err, "Grades should fit in uint8")
+}
+
+func TestBalancingStrategy_RoundTrip(t *testing.T) {
+	for _, strategy := range []*judgment.BalancingStrategy{
+		nil,
+		{Kind: judgment.BalancingNone},
+		{Kind: judgment.BalancingStatic, DefaultGrade: 3},
+		{Kind: judgment.BalancingMedian},
+	} {
+		message, err := BalancingStrategyToProto(strategy)
+		assert.NoError(t, err)
+		converted, err := BalancingStrategyFromProto(message)
+		assert.NoError(t, err)
+		assert.Equal(t, strategy, converted)
+	}
+
+	_, err := BalancingStrategyToProto(&judgment.BalancingStrategy{Kind: "mean"})
+	assert.Error(t, err)
+	_, err = BalancingStrategyFromProto(&pb.BalancingStrategy{Kind: 42})
+	assert.Error(t, err)
+	_, err = BalancingStrategyFromProto(&pb.BalancingStrategy{DefaultGrade: 300})
+	assert.Error(t, err)
+
+	unspecified, err := BalancingStrategyFromProto(&pb.BalancingStrategy{})
+	assert.NoError(t, err)
+	assert.Equal(t, judgment.BalancingNone, unspecified.Kind)
+}
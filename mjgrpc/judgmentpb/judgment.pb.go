@@ -0,0 +1,1008 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: mieuxvoter/majorityjudgment/v1/judgment.proto
+
+// Messages mirror the types of the judgment Go package ; see mjgrpc/convert.go.
+
+package judgmentpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BalancingStrategy_Kind int32
+
+const (
+	// Same as KIND_NONE for Deliberate, and as KIND_STATIC for the setup of StreamJudgments,
+	// whose tallies are unbalanced while judgments trickle in.
+	BalancingStrategy_KIND_UNSPECIFIED BalancingStrategy_Kind = 0
+	BalancingStrategy_KIND_NONE        BalancingStrategy_Kind = 1 // the tally is expected to be balanced already
+	BalancingStrategy_KIND_STATIC      BalancingStrategy_Kind = 2 // missing judgments are of default_grade
+	BalancingStrategy_KIND_MEDIAN      BalancingStrategy_Kind = 3 // missing judgments are of the median grade of each proposal
+)
+
+// Enum value maps for BalancingStrategy_Kind.
+var (
+	BalancingStrategy_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_NONE",
+		2: "KIND_STATIC",
+		3: "KIND_MEDIAN",
+	}
+	BalancingStrategy_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_NONE":        1,
+		"KIND_STATIC":      2,
+		"KIND_MEDIAN":      3,
+	}
+)
+
+func (x BalancingStrategy_Kind) Enum() *BalancingStrategy_Kind {
+	p := new(BalancingStrategy_Kind)
+	*p = x
+	return p
+}
+
+func (x BalancingStrategy_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalancingStrategy_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_enumTypes[0].Descriptor()
+}
+
+func (BalancingStrategy_Kind) Type() protoreflect.EnumType {
+	return &file_mieuxvoter_majorityjudgment_v1_judgment_proto_enumTypes[0]
+}
+
+func (x BalancingStrategy_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalancingStrategy_Kind.Descriptor instead.
+func (BalancingStrategy_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{5, 0}
+}
+
+// ProposalTally holds the amount of judgments received per grade for a single proposal.
+type ProposalTally struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Amount of judgments received for each grade, from "worst" grade to "best" grade.
+	Tally         []uint64 `protobuf:"varint,1,rep,packed,name=tally,proto3" json:"tally,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposalTally) Reset() {
+	*x = ProposalTally{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposalTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalTally) ProtoMessage() {}
+
+func (x *ProposalTally) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalTally.ProtoReflect.Descriptor instead.
+func (*ProposalTally) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProposalTally) GetTally() []uint64 {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+// PollTally describes the amount of judgments received by each proposal on each grade.
+type PollTally struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Helps balancing tallies using default judgments ; guessed when 0.
+	AmountOfJudges uint64 `protobuf:"varint,1,opt,name=amount_of_judges,json=amountOfJudges,proto3" json:"amount_of_judges,omitempty"`
+	// Tallies of each proposal.  Their order is preserved in the result.
+	Proposals     []*ProposalTally `protobuf:"bytes,2,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollTally) Reset() {
+	*x = PollTally{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollTally) ProtoMessage() {}
+
+func (x *PollTally) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollTally.ProtoReflect.Descriptor instead.
+func (*PollTally) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PollTally) GetAmountOfJudges() uint64 {
+	if x != nil {
+		return x.AmountOfJudges
+	}
+	return 0
+}
+
+func (x *PollTally) GetProposals() []*ProposalTally {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+// ProposalAnalysis holds the data used to compute the score of a proposal, and hence its rank.
+type ProposalAnalysis struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalSize         uint64                 `protobuf:"varint,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	MedianGrade       uint32                 `protobuf:"varint,2,opt,name=median_grade,json=medianGrade,proto3" json:"median_grade,omitempty"`
+	MedianGroupSize   uint64                 `protobuf:"varint,3,opt,name=median_group_size,json=medianGroupSize,proto3" json:"median_group_size,omitempty"`
+	SecondMedianGrade uint32                 `protobuf:"varint,4,opt,name=second_median_grade,json=secondMedianGrade,proto3" json:"second_median_grade,omitempty"`
+	SecondGroupSize   uint64                 `protobuf:"varint,5,opt,name=second_group_size,json=secondGroupSize,proto3" json:"second_group_size,omitempty"`
+	// -1 for the contestation group, +1 for the adhesion group, 0 when there is no second group.
+	SecondGroupSign        int32  `protobuf:"zigzag32,6,opt,name=second_group_sign,json=secondGroupSign,proto3" json:"second_group_sign,omitempty"`
+	AdhesionGroupGrade     uint32 `protobuf:"varint,7,opt,name=adhesion_group_grade,json=adhesionGroupGrade,proto3" json:"adhesion_group_grade,omitempty"`
+	AdhesionGroupSize      uint64 `protobuf:"varint,8,opt,name=adhesion_group_size,json=adhesionGroupSize,proto3" json:"adhesion_group_size,omitempty"`
+	ContestationGroupGrade uint32 `protobuf:"varint,9,opt,name=contestation_group_grade,json=contestationGroupGrade,proto3" json:"contestation_group_grade,omitempty"`
+	ContestationGroupSize  uint64 `protobuf:"varint,10,opt,name=contestation_group_size,json=contestationGroupSize,proto3" json:"contestation_group_size,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ProposalAnalysis) Reset() {
+	*x = ProposalAnalysis{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposalAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalAnalysis) ProtoMessage() {}
+
+func (x *ProposalAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalAnalysis.ProtoReflect.Descriptor instead.
+func (*ProposalAnalysis) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProposalAnalysis) GetTotalSize() uint64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetMedianGrade() uint32 {
+	if x != nil {
+		return x.MedianGrade
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetMedianGroupSize() uint64 {
+	if x != nil {
+		return x.MedianGroupSize
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetSecondMedianGrade() uint32 {
+	if x != nil {
+		return x.SecondMedianGrade
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetSecondGroupSize() uint64 {
+	if x != nil {
+		return x.SecondGroupSize
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetSecondGroupSign() int32 {
+	if x != nil {
+		return x.SecondGroupSign
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetAdhesionGroupGrade() uint32 {
+	if x != nil {
+		return x.AdhesionGroupGrade
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetAdhesionGroupSize() uint64 {
+	if x != nil {
+		return x.AdhesionGroupSize
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetContestationGroupGrade() uint32 {
+	if x != nil {
+		return x.ContestationGroupGrade
+	}
+	return 0
+}
+
+func (x *ProposalAnalysis) GetContestationGroupSize() uint64 {
+	if x != nil {
+		return x.ContestationGroupSize
+	}
+	return 0
+}
+
+// ProposalResult holds the computed rank of a proposal, as well as analysis data.
+type ProposalResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the proposal in the input proposals' tallies.
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Rank starts at 1 (best) and goes upwards.  Equal proposals share the same rank.
+	Rank uint32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Higher score lexicographically means better rank.
+	Score    string            `protobuf:"bytes,3,opt,name=score,proto3" json:"score,omitempty"`
+	Analysis *ProposalAnalysis `protobuf:"bytes,4,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	// The tally of grades that generated this result.
+	Tally         *ProposalTally `protobuf:"bytes,5,opt,name=tally,proto3" json:"tally,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProposalResult) Reset() {
+	*x = ProposalResult{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProposalResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalResult) ProtoMessage() {}
+
+func (x *ProposalResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalResult.ProtoReflect.Descriptor instead.
+func (*ProposalResult) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProposalResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ProposalResult) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ProposalResult) GetScore() string {
+	if x != nil {
+		return x.Score
+	}
+	return ""
+}
+
+func (x *ProposalResult) GetAnalysis() *ProposalAnalysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+func (x *ProposalResult) GetTally() *ProposalTally {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+// PollResult holds the result of each proposal, in the original proposal order.
+type PollResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matches the order of the input proposals' tallies.
+	Proposals []*ProposalResult `protobuf:"bytes,1,rep,name=proposals,proto3" json:"proposals,omitempty"`
+	// Indices of the proposals in the field above, sorted by rank.
+	SortedIndices []uint32 `protobuf:"varint,2,rep,packed,name=sorted_indices,json=sortedIndices,proto3" json:"sorted_indices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollResult) Reset() {
+	*x = PollResult{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollResult) ProtoMessage() {}
+
+func (x *PollResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollResult.ProtoReflect.Descriptor instead.
+func (*PollResult) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PollResult) GetProposals() []*ProposalResult {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+func (x *PollResult) GetSortedIndices() []uint32 {
+	if x != nil {
+		return x.SortedIndices
+	}
+	return nil
+}
+
+// BalancingStrategy describes how to balance a PollTally before deliberating it.
+type BalancingStrategy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  BalancingStrategy_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=mieuxvoter.majorityjudgment.v1.BalancingStrategy_Kind" json:"kind,omitempty"`
+	// Only used by KIND_STATIC.
+	DefaultGrade  uint32 `protobuf:"varint,2,opt,name=default_grade,json=defaultGrade,proto3" json:"default_grade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalancingStrategy) Reset() {
+	*x = BalancingStrategy{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalancingStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalancingStrategy) ProtoMessage() {}
+
+func (x *BalancingStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalancingStrategy.ProtoReflect.Descriptor instead.
+func (*BalancingStrategy) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BalancingStrategy) GetKind() BalancingStrategy_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return BalancingStrategy_KIND_UNSPECIFIED
+}
+
+func (x *BalancingStrategy) GetDefaultGrade() uint32 {
+	if x != nil {
+		return x.DefaultGrade
+	}
+	return 0
+}
+
+// Judgment is a single grade given by a judge to a proposal.
+type Judgment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the proposal in the poll's tally.
+	Proposal uint32 `protobuf:"varint,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// 0 == "worst" grade.
+	Grade         uint32 `protobuf:"varint,2,opt,name=grade,proto3" json:"grade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Judgment) Reset() {
+	*x = Judgment{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Judgment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Judgment) ProtoMessage() {}
+
+func (x *Judgment) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Judgment.ProtoReflect.Descriptor instead.
+func (*Judgment) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Judgment) GetProposal() uint32 {
+	if x != nil {
+		return x.Proposal
+	}
+	return 0
+}
+
+func (x *Judgment) GetGrade() uint32 {
+	if x != nil {
+		return x.Grade
+	}
+	return 0
+}
+
+type DeliberateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tally         *PollTally             `protobuf:"bytes,1,opt,name=tally,proto3" json:"tally,omitempty"`
+	Balancing     *BalancingStrategy     `protobuf:"bytes,2,opt,name=balancing,proto3" json:"balancing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliberateRequest) Reset() {
+	*x = DeliberateRequest{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliberateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliberateRequest) ProtoMessage() {}
+
+func (x *DeliberateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliberateRequest.ProtoReflect.Descriptor instead.
+func (*DeliberateRequest) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeliberateRequest) GetTally() *PollTally {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+func (x *DeliberateRequest) GetBalancing() *BalancingStrategy {
+	if x != nil {
+		return x.Balancing
+	}
+	return nil
+}
+
+type DeliberateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *PollResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliberateResponse) Reset() {
+	*x = DeliberateResponse{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliberateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliberateResponse) ProtoMessage() {}
+
+func (x *DeliberateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliberateResponse.ProtoReflect.Descriptor instead.
+func (*DeliberateResponse) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeliberateResponse) GetResult() *PollResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tally         *ProposalTally         `protobuf:"bytes,1,opt,name=tally,proto3" json:"tally,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AnalyzeRequest) GetTally() *ProposalTally {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Analysis      *ProposalAnalysis      `protobuf:"bytes,1,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AnalyzeResponse) GetAnalysis() *ProposalAnalysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+type StreamJudgmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*StreamJudgmentsRequest_Setup
+	//	*StreamJudgmentsRequest_Judgment
+	Payload       isStreamJudgmentsRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJudgmentsRequest) Reset() {
+	*x = StreamJudgmentsRequest{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJudgmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJudgmentsRequest) ProtoMessage() {}
+
+func (x *StreamJudgmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJudgmentsRequest.ProtoReflect.Descriptor instead.
+func (*StreamJudgmentsRequest) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamJudgmentsRequest) GetPayload() isStreamJudgmentsRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StreamJudgmentsRequest) GetSetup() *DeliberateRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamJudgmentsRequest_Setup); ok {
+			return x.Setup
+		}
+	}
+	return nil
+}
+
+func (x *StreamJudgmentsRequest) GetJudgment() *Judgment {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamJudgmentsRequest_Judgment); ok {
+			return x.Judgment
+		}
+	}
+	return nil
+}
+
+type isStreamJudgmentsRequest_Payload interface {
+	isStreamJudgmentsRequest_Payload()
+}
+
+type StreamJudgmentsRequest_Setup struct {
+	// Must be sent first, and only once: the shape of the poll, and the judgments received so far, if any.
+	// Without a balancing kind, missing judgments count as the default grade.
+	Setup *DeliberateRequest `protobuf:"bytes,1,opt,name=setup,proto3,oneof"`
+}
+
+type StreamJudgmentsRequest_Judgment struct {
+	// Then, judgments as they are received.
+	// When a proposal gets more judgments than the amount of judges of the setup, that amount grows.
+	Judgment *Judgment `protobuf:"bytes,2,opt,name=judgment,proto3,oneof"`
+}
+
+func (*StreamJudgmentsRequest_Setup) isStreamJudgmentsRequest_Payload() {}
+
+func (*StreamJudgmentsRequest_Judgment) isStreamJudgmentsRequest_Payload() {}
+
+type StreamJudgmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ranking after the setup, and after each judgment.
+	Result        *PollResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJudgmentsResponse) Reset() {
+	*x = StreamJudgmentsResponse{}
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJudgmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJudgmentsResponse) ProtoMessage() {}
+
+func (x *StreamJudgmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJudgmentsResponse.ProtoReflect.Descriptor instead.
+func (*StreamJudgmentsResponse) Descriptor() ([]byte, []int) {
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamJudgmentsResponse) GetResult() *PollResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_mieuxvoter_majorityjudgment_v1_judgment_proto protoreflect.FileDescriptor
+
+const file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDesc = "" +
+	"\n" +
+	"-mieuxvoter/majorityjudgment/v1/judgment.proto\x12\x1emieuxvoter.majorityjudgment.v1\"%\n" +
+	"\rProposalTally\x12\x14\n" +
+	"\x05tally\x18\x01 \x03(\x04R\x05tally\"\x82\x01\n" +
+	"\tPollTally\x12(\n" +
+	"\x10amount_of_judges\x18\x01 \x01(\x04R\x0eamountOfJudges\x12K\n" +
+	"\tproposals\x18\x02 \x03(\v2-.mieuxvoter.majorityjudgment.v1.ProposalTallyR\tproposals\"\xdc\x03\n" +
+	"\x10ProposalAnalysis\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x01 \x01(\x04R\ttotalSize\x12!\n" +
+	"\fmedian_grade\x18\x02 \x01(\rR\vmedianGrade\x12*\n" +
+	"\x11median_group_size\x18\x03 \x01(\x04R\x0fmedianGroupSize\x12.\n" +
+	"\x13second_median_grade\x18\x04 \x01(\rR\x11secondMedianGrade\x12*\n" +
+	"\x11second_group_size\x18\x05 \x01(\x04R\x0fsecondGroupSize\x12*\n" +
+	"\x11second_group_sign\x18\x06 \x01(\x11R\x0fsecondGroupSign\x120\n" +
+	"\x14adhesion_group_grade\x18\a \x01(\rR\x12adhesionGroupGrade\x12.\n" +
+	"\x13adhesion_group_size\x18\b \x01(\x04R\x11adhesionGroupSize\x128\n" +
+	"\x18contestation_group_grade\x18\t \x01(\rR\x16contestationGroupGrade\x126\n" +
+	"\x17contestation_group_size\x18\n" +
+	" \x01(\x04R\x15contestationGroupSize\"\xe3\x01\n" +
+	"\x0eProposalResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\rR\x04rank\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\tR\x05score\x12L\n" +
+	"\banalysis\x18\x04 \x01(\v20.mieuxvoter.majorityjudgment.v1.ProposalAnalysisR\banalysis\x12C\n" +
+	"\x05tally\x18\x05 \x01(\v2-.mieuxvoter.majorityjudgment.v1.ProposalTallyR\x05tally\"\x81\x01\n" +
+	"\n" +
+	"PollResult\x12L\n" +
+	"\tproposals\x18\x01 \x03(\v2..mieuxvoter.majorityjudgment.v1.ProposalResultR\tproposals\x12%\n" +
+	"\x0esorted_indices\x18\x02 \x03(\rR\rsortedIndices\"\xd3\x01\n" +
+	"\x11BalancingStrategy\x12J\n" +
+	"\x04kind\x18\x01 \x01(\x0e26.mieuxvoter.majorityjudgment.v1.BalancingStrategy.KindR\x04kind\x12#\n" +
+	"\rdefault_grade\x18\x02 \x01(\rR\fdefaultGrade\"M\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tKIND_NONE\x10\x01\x12\x0f\n" +
+	"\vKIND_STATIC\x10\x02\x12\x0f\n" +
+	"\vKIND_MEDIAN\x10\x03\"<\n" +
+	"\bJudgment\x12\x1a\n" +
+	"\bproposal\x18\x01 \x01(\rR\bproposal\x12\x14\n" +
+	"\x05grade\x18\x02 \x01(\rR\x05grade\"\xa5\x01\n" +
+	"\x11DeliberateRequest\x12?\n" +
+	"\x05tally\x18\x01 \x01(\v2).mieuxvoter.majorityjudgment.v1.PollTallyR\x05tally\x12O\n" +
+	"\tbalancing\x18\x02 \x01(\v21.mieuxvoter.majorityjudgment.v1.BalancingStrategyR\tbalancing\"X\n" +
+	"\x12DeliberateResponse\x12B\n" +
+	"\x06result\x18\x01 \x01(\v2*.mieuxvoter.majorityjudgment.v1.PollResultR\x06result\"U\n" +
+	"\x0eAnalyzeRequest\x12C\n" +
+	"\x05tally\x18\x01 \x01(\v2-.mieuxvoter.majorityjudgment.v1.ProposalTallyR\x05tally\"_\n" +
+	"\x0fAnalyzeResponse\x12L\n" +
+	"\banalysis\x18\x01 \x01(\v20.mieuxvoter.majorityjudgment.v1.ProposalAnalysisR\banalysis\"\xb6\x01\n" +
+	"\x16StreamJudgmentsRequest\x12I\n" +
+	"\x05setup\x18\x01 \x01(\v21.mieuxvoter.majorityjudgment.v1.DeliberateRequestH\x00R\x05setup\x12F\n" +
+	"\bjudgment\x18\x02 \x01(\v2(.mieuxvoter.majorityjudgment.v1.JudgmentH\x00R\bjudgmentB\t\n" +
+	"\apayload\"]\n" +
+	"\x17StreamJudgmentsResponse\x12B\n" +
+	"\x06result\x18\x01 \x01(\v2*.mieuxvoter.majorityjudgment.v1.PollResultR\x06result2\xfb\x02\n" +
+	"\x0fJudgmentService\x12s\n" +
+	"\n" +
+	"Deliberate\x121.mieuxvoter.majorityjudgment.v1.DeliberateRequest\x1a2.mieuxvoter.majorityjudgment.v1.DeliberateResponse\x12j\n" +
+	"\aAnalyze\x12..mieuxvoter.majorityjudgment.v1.AnalyzeRequest\x1a/.mieuxvoter.majorityjudgment.v1.AnalyzeResponse\x12\x86\x01\n" +
+	"\x0fStreamJudgments\x126.mieuxvoter.majorityjudgment.v1.StreamJudgmentsRequest\x1a7.mieuxvoter.majorityjudgment.v1.StreamJudgmentsResponse(\x010\x01BFZDgithub.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpbb\x06proto3"
+
+var (
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescOnce sync.Once
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescData []byte
+)
+
+func file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescGZIP() []byte {
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescOnce.Do(func() {
+		file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDesc), len(file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDesc)))
+	})
+	return file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDescData
+}
+
+var file_mieuxvoter_majorityjudgment_v1_judgment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_mieuxvoter_majorityjudgment_v1_judgment_proto_goTypes = []any{
+	(BalancingStrategy_Kind)(0),     // 0: mieuxvoter.majorityjudgment.v1.BalancingStrategy.Kind
+	(*ProposalTally)(nil),           // 1: mieuxvoter.majorityjudgment.v1.ProposalTally
+	(*PollTally)(nil),               // 2: mieuxvoter.majorityjudgment.v1.PollTally
+	(*ProposalAnalysis)(nil),        // 3: mieuxvoter.majorityjudgment.v1.ProposalAnalysis
+	(*ProposalResult)(nil),          // 4: mieuxvoter.majorityjudgment.v1.ProposalResult
+	(*PollResult)(nil),              // 5: mieuxvoter.majorityjudgment.v1.PollResult
+	(*BalancingStrategy)(nil),       // 6: mieuxvoter.majorityjudgment.v1.BalancingStrategy
+	(*Judgment)(nil),                // 7: mieuxvoter.majorityjudgment.v1.Judgment
+	(*DeliberateRequest)(nil),       // 8: mieuxvoter.majorityjudgment.v1.DeliberateRequest
+	(*DeliberateResponse)(nil),      // 9: mieuxvoter.majorityjudgment.v1.DeliberateResponse
+	(*AnalyzeRequest)(nil),          // 10: mieuxvoter.majorityjudgment.v1.AnalyzeRequest
+	(*AnalyzeResponse)(nil),         // 11: mieuxvoter.majorityjudgment.v1.AnalyzeResponse
+	(*StreamJudgmentsRequest)(nil),  // 12: mieuxvoter.majorityjudgment.v1.StreamJudgmentsRequest
+	(*StreamJudgmentsResponse)(nil), // 13: mieuxvoter.majorityjudgment.v1.StreamJudgmentsResponse
+}
+var file_mieuxvoter_majorityjudgment_v1_judgment_proto_depIdxs = []int32{
+	1,  // 0: mieuxvoter.majorityjudgment.v1.PollTally.proposals:type_name -> mieuxvoter.majorityjudgment.v1.ProposalTally
+	3,  // 1: mieuxvoter.majorityjudgment.v1.ProposalResult.analysis:type_name -> mieuxvoter.majorityjudgment.v1.ProposalAnalysis
+	1,  // 2: mieuxvoter.majorityjudgment.v1.ProposalResult.tally:type_name -> mieuxvoter.majorityjudgment.v1.ProposalTally
+	4,  // 3: mieuxvoter.majorityjudgment.v1.PollResult.proposals:type_name -> mieuxvoter.majorityjudgment.v1.ProposalResult
+	0,  // 4: mieuxvoter.majorityjudgment.v1.BalancingStrategy.kind:type_name -> mieuxvoter.majorityjudgment.v1.BalancingStrategy.Kind
+	2,  // 5: mieuxvoter.majorityjudgment.v1.DeliberateRequest.tally:type_name -> mieuxvoter.majorityjudgment.v1.PollTally
+	6,  // 6: mieuxvoter.majorityjudgment.v1.DeliberateRequest.balancing:type_name -> mieuxvoter.majorityjudgment.v1.BalancingStrategy
+	5,  // 7: mieuxvoter.majorityjudgment.v1.DeliberateResponse.result:type_name -> mieuxvoter.majorityjudgment.v1.PollResult
+	1,  // 8: mieuxvoter.majorityjudgment.v1.AnalyzeRequest.tally:type_name -> mieuxvoter.majorityjudgment.v1.ProposalTally
+	3,  // 9: mieuxvoter.majorityjudgment.v1.AnalyzeResponse.analysis:type_name -> mieuxvoter.majorityjudgment.v1.ProposalAnalysis
+	8,  // 10: mieuxvoter.majorityjudgment.v1.StreamJudgmentsRequest.setup:type_name -> mieuxvoter.majorityjudgment.v1.DeliberateRequest
+	7,  // 11: mieuxvoter.majorityjudgment.v1.StreamJudgmentsRequest.judgment:type_name -> mieuxvoter.majorityjudgment.v1.Judgment
+	5,  // 12: mieuxvoter.majorityjudgment.v1.StreamJudgmentsResponse.result:type_name -> mieuxvoter.majorityjudgment.v1.PollResult
+	8,  // 13: mieuxvoter.majorityjudgment.v1.JudgmentService.Deliberate:input_type -> mieuxvoter.majorityjudgment.v1.DeliberateRequest
+	10, // 14: mieuxvoter.majorityjudgment.v1.JudgmentService.Analyze:input_type -> mieuxvoter.majorityjudgment.v1.AnalyzeRequest
+	12, // 15: mieuxvoter.majorityjudgment.v1.JudgmentService.StreamJudgments:input_type -> mieuxvoter.majorityjudgment.v1.StreamJudgmentsRequest
+	9,  // 16: mieuxvoter.majorityjudgment.v1.JudgmentService.Deliberate:output_type -> mieuxvoter.majorityjudgment.v1.DeliberateResponse
+	11, // 17: mieuxvoter.majorityjudgment.v1.JudgmentService.Analyze:output_type -> mieuxvoter.majorityjudgment.v1.AnalyzeResponse
+	13, // 18: mieuxvoter.majorityjudgment.v1.JudgmentService.StreamJudgments:output_type -> mieuxvoter.majorityjudgment.v1.StreamJudgmentsResponse
+	16, // [16:19] is the sub-list for method output_type
+	13, // [13:16] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_mieuxvoter_majorityjudgment_v1_judgment_proto_init() }
+func file_mieuxvoter_majorityjudgment_v1_judgment_proto_init() {
+	if File_mieuxvoter_majorityjudgment_v1_judgment_proto != nil {
+		return
+	}
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes[11].OneofWrappers = []any{
+		(*StreamJudgmentsRequest_Setup)(nil),
+		(*StreamJudgmentsRequest_Judgment)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDesc), len(file_mieuxvoter_majorityjudgment_v1_judgment_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mieuxvoter_majorityjudgment_v1_judgment_proto_goTypes,
+		DependencyIndexes: file_mieuxvoter_majorityjudgment_v1_judgment_proto_depIdxs,
+		EnumInfos:         file_mieuxvoter_majorityjudgment_v1_judgment_proto_enumTypes,
+		MessageInfos:      file_mieuxvoter_majorityjudgment_v1_judgment_proto_msgTypes,
+	}.Build()
+	File_mieuxvoter_majorityjudgment_v1_judgment_proto = out.File
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_goTypes = nil
+	file_mieuxvoter_majorityjudgment_v1_judgment_proto_depIdxs = nil
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mieuxvoter/majorityjudgment/v1/judgment.proto
+
+// Messages mirror the types of the judgment Go package ; see mjgrpc/convert.go.
+
+package judgmentpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JudgmentService_Deliberate_FullMethodName      = "/mieuxvoter.majorityjudgment.v1.JudgmentService/Deliberate"
+	JudgmentService_Analyze_FullMethodName         = "/mieuxvoter.majorityjudgment.v1.JudgmentService/Analyze"
+	JudgmentService_StreamJudgments_FullMethodName = "/mieuxvoter.majorityjudgment.v1.JudgmentService/StreamJudgments"
+)
+
+// JudgmentServiceClient is the client API for JudgmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JudgmentService deliberates polls using Majority Judgment.
+type JudgmentServiceClient interface {
+	// Deliberate balances the tally if asked to, and ranks the proposals.
+	Deliberate(ctx context.Context, in *DeliberateRequest, opts ...grpc.CallOption) (*DeliberateResponse, error)
+	// Analyze computes the median and groups of a single proposal.
+	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// StreamJudgments accepts judgments as they arrive, and emits the updated rankings.
+	StreamJudgments(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamJudgmentsRequest, StreamJudgmentsResponse], error)
+}
+
+type judgmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJudgmentServiceClient(cc grpc.ClientConnInterface) JudgmentServiceClient {
+	return &judgmentServiceClient{cc}
+}
+
+func (c *judgmentServiceClient) Deliberate(ctx context.Context, in *DeliberateRequest, opts ...grpc.CallOption) (*DeliberateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeliberateResponse)
+	err := c.cc.Invoke(ctx, JudgmentService_Deliberate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *judgmentServiceClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeResponse)
+	err := c.cc.Invoke(ctx, JudgmentService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *judgmentServiceClient) StreamJudgments(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamJudgmentsRequest, StreamJudgmentsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JudgmentService_ServiceDesc.Streams[0], JudgmentService_StreamJudgments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamJudgmentsRequest, StreamJudgmentsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JudgmentService_StreamJudgmentsClient = grpc.BidiStreamingClient[StreamJudgmentsRequest, StreamJudgmentsResponse]
+
+// JudgmentServiceServer is the server API for JudgmentService service.
+// All implementations must embed UnimplementedJudgmentServiceServer
+// for forward compatibility.
+//
+// JudgmentService deliberates polls using Majority Judgment.
+type JudgmentServiceServer interface {
+	// Deliberate balances the tally if asked to, and ranks the proposals.
+	Deliberate(context.Context, *DeliberateRequest) (*DeliberateResponse, error)
+	// Analyze computes the median and groups of a single proposal.
+	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	// StreamJudgments accepts judgments as they arrive, and emits the updated rankings.
+	StreamJudgments(grpc.BidiStreamingServer[StreamJudgmentsRequest, StreamJudgmentsResponse]) error
+	mustEmbedUnimplementedJudgmentServiceServer()
+}
+
+// UnimplementedJudgmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJudgmentServiceServer struct{}
+
+func (UnimplementedJudgmentServiceServer) Deliberate(context.Context, *DeliberateRequest) (*DeliberateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deliberate not implemented")
+}
+func (UnimplementedJudgmentServiceServer) Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedJudgmentServiceServer) StreamJudgments(grpc.BidiStreamingServer[StreamJudgmentsRequest, StreamJudgmentsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamJudgments not implemented")
+}
+func (UnimplementedJudgmentServiceServer) mustEmbedUnimplementedJudgmentServiceServer() {}
+func (UnimplementedJudgmentServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeJudgmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JudgmentServiceServer will
+// result in compilation errors.
+type UnsafeJudgmentServiceServer interface {
+	mustEmbedUnimplementedJudgmentServiceServer()
+}
+
+func RegisterJudgmentServiceServer(s grpc.ServiceRegistrar, srv JudgmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJudgmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JudgmentService_ServiceDesc, srv)
+}
+
+func _JudgmentService_Deliberate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliberateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JudgmentServiceServer).Deliberate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JudgmentService_Deliberate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JudgmentServiceServer).Deliberate(ctx, req.(*DeliberateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JudgmentService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JudgmentServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JudgmentService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JudgmentServiceServer).Analyze(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JudgmentService_StreamJudgments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JudgmentServiceServer).StreamJudgments(&grpc.GenericServerStream[StreamJudgmentsRequest, StreamJudgmentsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JudgmentService_StreamJudgmentsServer = grpc.BidiStreamingServer[StreamJudgmentsRequest, StreamJudgmentsResponse]
+
+// JudgmentService_ServiceDesc is the grpc.ServiceDesc for JudgmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JudgmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mieuxvoter.majorityjudgment.v1.JudgmentService",
+	HandlerType: (*JudgmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Deliberate",
+			Handler:    _JudgmentService_Deliberate_Handler,
+		},
+		{
+			MethodName: "Analyze",
+			Handler:    _JudgmentService_Analyze_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamJudgments",
+			Handler:       _JudgmentService_StreamJudgments_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "mieuxvoter/majorityjudgment/v1/judgment.proto",
+}
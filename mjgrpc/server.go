@@ -0,0 +1,140 @@
+package mjgrpc
+
+import (
+	"context"
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	pb "github.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+// Server implements the JudgmentService using the MajorityJudgment deliberator.
+type Server struct {
+	pb.UnimplementedJudgmentServiceServer
+}
+
+// NewServer returns a Server, ready to be registered with pb.RegisterJudgmentServiceServer.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Deliberate is part of the JudgmentService
+func (s *Server) Deliberate(ctx context.Context, request *pb.DeliberateRequest) (*pb.DeliberateResponse, error) {
+	pollTally, balancing, err := setupFromProto(request)
+	if nil != err {
+		return nil, err
+	}
+	result, err := deliberate(pollTally, balancing)
+	if nil != err {
+		return nil, err
+	}
+	return &pb.DeliberateResponse{Result: PollResultToProto(result)}, nil
+}
+
+// Analyze is part of the JudgmentService
+func (s *Server) Analyze(ctx context.Context, request *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	proposalTally := ProposalTallyFromProto(request.GetTally())
+	return &pb.AnalyzeResponse{Analysis: ProposalAnalysisToProto(proposalTally.Analyze())}, nil
+}
+
+// StreamJudgments is part of the JudgmentService.
+// The first message must be a setup, and the following ones judgments.
+// A result is emitted after the setup, and after each judgment.
+// Since judgments trickle in, tallies are usually unbalanced: without a balancing kind in the setup,
+// missing judgments count as the default grade, and the amount of judges grows with the judgments.
+func (s *Server) StreamJudgments(stream pb.JudgmentService_StreamJudgmentsServer) error {
+	var pollTally *judgment.PollTally
+	var balancing *judgment.BalancingStrategy
+
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if nil != err {
+			return err
+		}
+
+		switch payload := request.GetPayload().(type) {
+		case *pb.StreamJudgmentsRequest_Setup:
+			if nil != pollTally {
+				return status.Error(codes.FailedPrecondition, "the stream was already set up")
+			}
+			pollTally, balancing, err = setupFromProto(payload.Setup)
+			if nil != err {
+				return err
+			}
+			if pb.BalancingStrategy_KIND_UNSPECIFIED == payload.Setup.GetBalancing().GetKind() {
+				balancing = &judgment.BalancingStrategy{
+					Kind:         judgment.BalancingStatic,
+					DefaultGrade: uint8(payload.Setup.GetBalancing().GetDefaultGrade()),
+				}
+			}
+		case *pb.StreamJudgmentsRequest_Judgment:
+			if nil == pollTally {
+				return status.Error(codes.FailedPrecondition, "the first message of the stream must be a setup")
+			}
+			proposal := payload.Judgment.GetProposal()
+			grade := payload.Judgment.GetGrade()
+			if int(proposal) >= len(pollTally.Proposals) {
+				return status.Errorf(codes.InvalidArgument, "no proposal #%d in this poll", proposal)
+			}
+			if int(grade) >= len(pollTally.Proposals[proposal].Tally) {
+				return status.Errorf(codes.InvalidArgument, "no grade #%d in this poll", grade)
+			}
+			pollTally.Proposals[proposal].Tally[grade]++
+			// A new judge showed up ; an amount of 0 is guessed anyway.
+			amountOfJudgments := pollTally.Proposals[proposal].CountJudgments()
+			if 0 != pollTally.AmountOfJudges && amountOfJudgments > pollTally.AmountOfJudges {
+				pollTally.AmountOfJudges = amountOfJudgments
+			}
+		default:
+			return status.Error(codes.InvalidArgument, "empty message")
+		}
+
+		result, err := deliberate(pollTally, balancing)
+		if nil != err {
+			return err
+		}
+		if err := stream.Send(&pb.StreamJudgmentsResponse{Result: PollResultToProto(result)}); nil != err {
+			return err
+		}
+	}
+}
+
+func setupFromProto(request *pb.DeliberateRequest) (*judgment.PollTally, *judgment.BalancingStrategy, error) {
+	if nil == request.GetTally() {
+		return nil, nil, status.Error(codes.InvalidArgument, "tally is required")
+	}
+	balancing, err := BalancingStrategyFromProto(request.GetBalancing())
+	if nil != err {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return PollTallyFromProto(request.GetTally()), balancing, nil
+}
+
+// deliberate balances a copy of the tally, so that the original keeps counting actual judgments only.
+func deliberate(pollTally *judgment.PollTally, balancing *judgment.BalancingStrategy) (*judgment.PollResult, error) {
+	balanced := pollTally.Copy()
+	if err := balanced.Balance(balancing); nil != err {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	deliberator := &judgment.MajorityJudgment{}
+	result, err := deliberator.Deliberate(balanced)
+	if nil != err {
+		code := codes.Internal
+		switch {
+		case errors.Is(err, judgment.ErrMishapedTally), errors.Is(err, judgment.ErrIncoherentTally):
+			code = codes.InvalidArgument
+		case errors.Is(err, judgment.ErrUnbalancedTally):
+			code = codes.FailedPrecondition
+		case errors.Is(err, judgment.ErrTooManyJudgments):
+			code = codes.OutOfRange
+		}
+		return nil, status.Error(code, err.Error())
+	}
+	return result, nil
+}
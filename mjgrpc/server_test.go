@@ -0,0 +1,169 @@
+package mjgrpc
+
+import (
+	"context"
+	pb "github.com/mieuxvoter/majority-judgment-library-go/mjgrpc/judgmentpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+)
+
+func newTestClient(t *testing.T) pb.JudgmentServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterJudgmentServiceServer(server, NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+
+	return pb.NewJudgmentServiceClient(connection)
+}
+
+func TestServer_Deliberate(t *testing.T) {
+	client := newTestClient(t)
+	response, err := client.Deliberate(context.Background(), &pb.DeliberateRequest{
+		Tally: &pb.PollTally{Proposals: []*pb.ProposalTally{
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+			{Tally: []uint64{2, 1, 1, 1, 5}},
+			{Tally: []uint64{0, 0, 1, 0, 0}},
+		}},
+		Balancing: &pb.BalancingStrategy{Kind: pb.BalancingStrategy_KIND_STATIC},
+	})
+	assert.NoError(t, err, "Deliberation should succeed")
+	result := response.GetResult()
+	assert.Equal(t, []uint32{1, 0, 2}, result.GetSortedIndices())
+	assert.Equal(t, []uint64{9, 0, 1, 0, 0}, result.GetProposals()[2].GetTally().GetTally())
+}
+
+func TestServer_Deliberate_Failures(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.Deliberate(context.Background(), &pb.DeliberateRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Deliberate(context.Background(), &pb.DeliberateRequest{
+		Tally: &pb.PollTally{Proposals: []*pb.ProposalTally{{Tally: []uint64{1, 2}}, {Tally: []uint64{1}}}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Mishaped tally")
+
+	_, err = client.Deliberate(context.Background(), &pb.DeliberateRequest{
+		Tally: &pb.PollTally{Proposals: []*pb.ProposalTally{{Tally: []uint64{1, 2}}, {Tally: []uint64{1, 1}}}},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Unbalanced tally")
+}
+
+func TestServer_Analyze(t *testing.T) {
+	client := newTestClient(t)
+	response, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{
+		Tally: &pb.ProposalTally{Tally: []uint64{2, 1, 1, 1, 5}},
+	})
+	assert.NoError(t, err, "Analysis should succeed")
+	assert.Equal(t, uint32(3), response.GetAnalysis().GetMedianGrade())
+	assert.Equal(t, int32(1), response.GetAnalysis().GetSecondGroupSign())
+}
+
+func TestServer_StreamJudgments(t *testing.T) {
+	client := newTestClient(t)
+	stream, err := client.StreamJudgments(context.Background())
+	assert.NoError(t, err)
+
+	assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{Payload: &pb.StreamJudgmentsRequest_Setup{
+		Setup: &pb.DeliberateRequest{
+			Tally: &pb.PollTally{Proposals: []*pb.ProposalTally{
+				{Tally: []uint64{0, 0, 0}},
+				{Tally: []uint64{0, 0, 0}},
+			}},
+			Balancing: &pb.BalancingStrategy{Kind: pb.BalancingStrategy_KIND_STATIC},
+		},
+	}}))
+	response, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Len(t, response.GetResult().GetProposals(), 2)
+
+	judgments := []*pb.Judgment{
+		{Proposal: 1, Grade: 2},
+		{Proposal: 0, Grade: 1},
+		{Proposal: 0, Grade: 2},
+	}
+	leaders := []uint32{1, 1, 0}
+	for judgmentIndex, judgment := range judgments {
+		assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{
+			Payload: &pb.StreamJudgmentsRequest_Judgment{Judgment: judgment},
+		}))
+		response, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, leaders[judgmentIndex], response.GetResult().GetSortedIndices()[0], "Leader after judgment #%d", judgmentIndex)
+	}
+	// Judgments are counted as is, and the balancing only applies to deliberation.
+	assert.Equal(t, []uint64{0, 1, 1}, response.GetResult().GetProposals()[0].GetTally().GetTally()[0:3])
+
+	assert.NoError(t, stream.CloseSend())
+}
+
+func TestServer_StreamJudgments_DefaultSetup(t *testing.T) {
+	client := newTestClient(t)
+	for _, amountOfJudges := range []uint64{0, 1} {
+		stream, err := client.StreamJudgments(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{Payload: &pb.StreamJudgmentsRequest_Setup{
+			Setup: &pb.DeliberateRequest{Tally: &pb.PollTally{
+				AmountOfJudges: amountOfJudges,
+				Proposals: []*pb.ProposalTally{
+					{Tally: []uint64{0, 0, 0}},
+					{Tally: []uint64{0, 0, 0}},
+				},
+			}},
+		}}))
+		_, err = stream.Recv()
+		assert.NoError(t, err)
+
+		var response *pb.StreamJudgmentsResponse
+		for _, judgment := range []*pb.Judgment{{Proposal: 0, Grade: 2}, {Proposal: 0, Grade: 1}, {Proposal: 1, Grade: 2}} {
+			assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{
+				Payload: &pb.StreamJudgmentsRequest_Judgment{Judgment: judgment},
+			}))
+			response, err = stream.Recv()
+			assert.NoError(t, err, "Judgments should trickle in with %d judges in the setup", amountOfJudges)
+		}
+		assert.Equal(t, uint32(0), response.GetResult().GetSortedIndices()[0], "Missing judgments should count as the worst grade")
+		assert.NoError(t, stream.CloseSend())
+	}
+}
+
+func TestServer_StreamJudgments_Failures(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.StreamJudgments(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{
+		Payload: &pb.StreamJudgmentsRequest_Judgment{Judgment: &pb.Judgment{}},
+	}))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Setup is required first")
+
+	stream, err = client.StreamJudgments(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{Payload: &pb.StreamJudgmentsRequest_Setup{
+		Setup: &pb.DeliberateRequest{Tally: &pb.PollTally{Proposals: []*pb.ProposalTally{{Tally: []uint64{0, 0}}}}},
+	}}))
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&pb.StreamJudgmentsRequest{
+		Payload: &pb.StreamJudgmentsRequest_Judgment{Judgment: &pb.Judgment{Proposal: 3}},
+	}))
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Unknown proposal")
+}
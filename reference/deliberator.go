@@ -0,0 +1,135 @@
+// Package reference holds a second, deliberately naive, Majority Judgment deliberator,
+// and a differential checker comparing it with the score-based one of the judgment package.
+//
+// Auditors get two independent derivations of the same ranking: the clever scores of MajorityJudgment.ComputeScore,
+// and the textbook procedure of Balinski and Laraki implemented here on expanded judgments.
+package reference
+
+import (
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"sort"
+)
+
+// DefaultMaxJudgments is the default limit of judgments expanded per proposal.
+// Majority values take a time quadratic in the amount of judgments, so mind raising it.
+const DefaultMaxJudgments = 100000
+
+// Deliberator ranks proposals by their majority values: the sequence of the median grades
+// obtained by removing median judgments one by one, compared lexicographically.
+// It implements judgment.DeliberatorInterface, but leaves the scores of its results empty.
+type Deliberator struct {
+	MaxJudgments uint64 // judgments expanded per proposal, defaults to DefaultMaxJudgments
+}
+
+// Deliberate is part of the DeliberatorInterface
+func (deliberator *Deliberator) Deliberate(tally *judgment.PollTally) (_ *judgment.PollResult, err error) {
+	if err = deliberator.validate(tally); nil != err {
+		return nil, err
+	}
+
+	values := make([][]uint8, 0, len(tally.Proposals))
+	result := &judgment.PollResult{
+		Proposals:       make(judgment.ProposalsResults, 0, len(tally.Proposals)),
+		ProposalsSorted: make(judgment.ProposalsResults, 0, len(tally.Proposals)),
+	}
+	for proposalIndex, proposalTally := range tally.Proposals {
+		values = append(values, MajorityValue(expand(proposalTally)))
+		proposalResult := &judgment.ProposalResult{
+			Index:    proposalIndex,
+			Analysis: proposalTally.Analyze(),
+			Tally:    proposalTally,
+		}
+		result.Proposals = append(result.Proposals, proposalResult)
+		result.ProposalsSorted = append(result.ProposalsSorted, proposalResult)
+	}
+
+	sort.SliceStable(result.ProposalsSorted, func(i, j int) bool {
+		return compareValues(values[result.ProposalsSorted[i].Index], values[result.ProposalsSorted[j].Index]) > 0
+	})
+	for position, proposalResult := range result.ProposalsSorted {
+		proposalResult.Rank = position + 1
+		if position > 0 {
+			previous := result.ProposalsSorted[position-1]
+			if 0 == compareValues(values[previous.Index], values[proposalResult.Index]) {
+				proposalResult.Rank = previous.Rank
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// MajorityValue returns the successive low median grades of the judgments, removing the median judgment each time.
+// The judgments must be sorted from "worst" to "best" grade ; they are left intact.
+// Each removal shifts the remaining judgments, so this takes a time quadratic in their amount.
+func MajorityValue(judgments []uint8) []uint8 {
+	remaining := append([]uint8{}, judgments...)
+	value := make([]uint8, 0, len(judgments))
+	for len(remaining) > 0 {
+		median := (len(remaining) - 1) / 2 // the low median, when there are two
+		value = append(value, remaining[median])
+		remaining = append(remaining[:median], remaining[median+1:]...)
+	}
+	return value
+}
+
+// compareValues compares majority values lexicographically, returning 1 when a is better, -1 when b is, 0 otherwise.
+func compareValues(a []uint8, b []uint8) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// expand lists the judgments of the tally, sorted from "worst" to "best" grade.
+func expand(proposalTally *judgment.ProposalTally) []uint8 {
+	judgments := make([]uint8, 0, proposalTally.CountJudgments())
+	for grade, amount := range proposalTally.Tally {
+		for i := uint64(0); i < amount; i++ {
+			judgments = append(judgments, uint8(grade))
+		}
+	}
+	return judgments
+}
+
+// validate checks the tally like MajorityJudgment does, with the same errors, guessing the amount of judges if need be.
+func (deliberator *Deliberator) validate(tally *judgment.PollTally) (err error) {
+	maxJudgments := deliberator.MaxJudgments
+	if 0 == maxJudgments {
+		maxJudgments = DefaultMaxJudgments
+	}
+	if 0 == tally.AmountOfJudges {
+		tally.GuessAmountOfJudges()
+	}
+	for proposalIndex, proposalTally := range tally.Proposals {
+		if len(proposalTally.Tally) != len(tally.Proposals[0].Tally) {
+			return fmt.Errorf("%w: proposal #%d holds %d grades instead of %d",
+				judgment.ErrMishapedTally, proposalIndex, len(proposalTally.Tally), len(tally.Proposals[0].Tally))
+		}
+	}
+	for proposalIndex, proposalTally := range tally.Proposals {
+		amountOfJudgments := uint64(0)
+		for _, amount := range proposalTally.Tally {
+			if amountOfJudgments+amount < amountOfJudgments || amountOfJudgments+amount > maxJudgments {
+				return fmt.Errorf("%w: proposal #%d holds more than %d judgments, too many to expand",
+					judgment.ErrTooManyJudgments, proposalIndex, maxJudgments)
+			}
+			amountOfJudgments += amount
+		}
+		if amountOfJudgments > tally.AmountOfJudges {
+			return fmt.Errorf("%w: proposal #%d holds %d judgments, but there are %d judges",
+				judgment.ErrIncoherentTally, proposalIndex, amountOfJudgments, tally.AmountOfJudges)
+		}
+		if amountOfJudgments < tally.AmountOfJudges {
+			return fmt.Errorf("%w: proposal #%d holds %d judgments, but there are %d judges",
+				judgment.ErrUnbalancedTally, proposalIndex, amountOfJudgments, tally.AmountOfJudges)
+		}
+	}
+	return nil
+}
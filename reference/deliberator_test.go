@@ -0,0 +1,49 @@
+package reference
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/conformance"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMajorityValue(t *testing.T) {
+	judgments := []uint8{0, 1, 1, 2, 3}
+	assert.Equal(t, []uint8{1, 1, 2, 0, 3}, MajorityValue(judgments))
+	assert.Equal(t, []uint8{0, 1, 1, 2, 3}, judgments, "Judgments should be left intact")
+	assert.Equal(t, []uint8{1, 2, 0, 3}, MajorityValue([]uint8{0, 1, 2, 3}), "The low median should come first")
+	assert.Empty(t, MajorityValue([]uint8{}))
+}
+
+func TestDeliberator_Deliberate(t *testing.T) {
+	pollTally := &judgment.PollTally{
+		Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+			{Tally: []uint64{2, 1, 1, 1, 5}},
+			{Tally: []uint64{2, 1, 1, 2, 4}},
+			{Tally: []uint64{2, 1, 5, 0, 2}},
+			{Tally: []uint64{2, 2, 2, 2, 2}},
+		},
+	}
+	result, err := (&Deliberator{}).Deliberate(pollTally)
+	assert.NoError(t, err, "Deliberation should succeed")
+	ranks := []int{}
+	for _, proposalResult := range result.Proposals {
+		ranks = append(ranks, proposalResult.Rank)
+	}
+	assert.Equal(t, []int{4, 1, 2, 3, 4}, ranks)
+	sortedIndices := []int{}
+	for _, proposalResult := range result.ProposalsSorted {
+		sortedIndices = append(sortedIndices, proposalResult.Index)
+	}
+	assert.Equal(t, []int{1, 2, 3, 0, 4}, sortedIndices)
+	assert.Equal(t, uint64(10), pollTally.AmountOfJudges, "The amount of judges should be guessed")
+}
+
+// The reference passes the conformance suite, ranks only, but for the polls too big to expand.
+func TestDeliberator_Conformance(t *testing.T) {
+	failures := conformance.Check(&Deliberator{}, conformance.Cases(), nil)
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "billions of judges", failures[0].Case)
+	assert.Contains(t, failures[0].Message, "too many judgments")
+}
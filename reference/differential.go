@@ -0,0 +1,131 @@
+package reference
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"math/rand"
+)
+
+// DifferentialOptions describes the random tallies of a differential check.  Zero fields get small defaults,
+// since small polls are where ties, even amounts of judges and other edge cases abound.
+type DifferentialOptions struct {
+	AmountOfTrials       int // defaults to 1000
+	MaxAmountOfProposals int // defaults to 5
+	MaxAmountOfGrades    int // defaults to 5
+	MaxAmountOfJudges    int // defaults to 12
+	Seed                 int64
+}
+
+// Disagreement is a tally on which two deliberators disagree.
+type Disagreement struct {
+	Trial     int
+	Tally     *judgment.PollTally
+	Reference []int // ranks given by the reference deliberator, nil if it failed
+	Candidate []int // ranks given by the candidate deliberator, nil if it failed
+	Reason    string
+}
+
+// String describes the disagreement for humans.
+func (disagreement Disagreement) String() string {
+	tallies := make([][]uint64, 0, len(disagreement.Tally.Proposals))
+	for _, proposalTally := range disagreement.Tally.Proposals {
+		tallies = append(tallies, proposalTally.Tally)
+	}
+	return fmt.Sprintf("trial #%d, tallies %v: %s", disagreement.Trial, tallies, disagreement.Reason)
+}
+
+// Differential deliberates random balanced tallies with both deliberators, and returns their disagreements
+// on ranks and on errors.  Trial t uses the seed Seed + t, so that any disagreement can be replayed on its own.
+func Differential(reference judgment.DeliberatorInterface, candidate judgment.DeliberatorInterface, options *DifferentialOptions) (disagreements []Disagreement) {
+	settings := DifferentialOptions{}
+	if nil != options {
+		settings = *options
+	}
+	if settings.AmountOfTrials < 1 {
+		settings.AmountOfTrials = 1000
+	}
+	if settings.MaxAmountOfProposals < 1 {
+		settings.MaxAmountOfProposals = 5
+	}
+	if settings.MaxAmountOfGrades < 1 {
+		settings.MaxAmountOfGrades = 5
+	}
+	if settings.MaxAmountOfJudges < 1 {
+		settings.MaxAmountOfJudges = 12
+	}
+
+	for trial := 0; trial < settings.AmountOfTrials; trial++ {
+		random := rand.New(rand.NewSource(settings.Seed + int64(trial)))
+		pollTally := randomTally(random, &settings)
+
+		// Deliberators may mutate the tally, so each gets its own.
+		referenceRanks, referenceErr := ranksOf(reference, pollTally.Copy())
+		candidateRanks, candidateErr := ranksOf(candidate, pollTally.Copy())
+		reason := ""
+		switch {
+		case nil != referenceErr && nil != candidateErr:
+			continue
+		case nil != referenceErr:
+			reason = fmt.Sprintf("only the reference failed: %v", referenceErr)
+		case nil != candidateErr:
+			reason = fmt.Sprintf("only the candidate failed: %v", candidateErr)
+		default:
+			for proposalIndex := range referenceRanks {
+				if referenceRanks[proposalIndex] != candidateRanks[proposalIndex] {
+					reason = fmt.Sprintf("ranks %v and %v differ", referenceRanks, candidateRanks)
+					break
+				}
+			}
+		}
+		if "" != reason {
+			disagreements = append(disagreements, Disagreement{
+				Trial:     trial,
+				Tally:     pollTally,
+				Reference: referenceRanks,
+				Candidate: candidateRanks,
+				Reason:    reason,
+			})
+		}
+	}
+	return disagreements
+}
+
+// randomTally makes up a balanced tally.  Some proposals copy another one, so that ties happen.
+func randomTally(random *rand.Rand, settings *DifferentialOptions) *judgment.PollTally {
+	amountOfProposals := 1 + random.Intn(settings.MaxAmountOfProposals)
+	amountOfGrades := 1 + random.Intn(settings.MaxAmountOfGrades)
+	amountOfJudges := random.Intn(settings.MaxAmountOfJudges + 1)
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: uint64(amountOfJudges),
+		Proposals:      make([]*judgment.ProposalTally, 0, amountOfProposals),
+	}
+	for proposalIndex := 0; proposalIndex < amountOfProposals; proposalIndex++ {
+		if proposalIndex > 0 && 0 == random.Intn(4) {
+			pollTally.Proposals = append(pollTally.Proposals, pollTally.Proposals[random.Intn(proposalIndex)].Copy())
+			continue
+		}
+		proposalTally := &judgment.ProposalTally{Tally: make([]uint64, amountOfGrades)}
+		for judge := 0; judge < amountOfJudges; judge++ {
+			proposalTally.Tally[random.Intn(amountOfGrades)]++
+		}
+		pollTally.Proposals = append(pollTally.Proposals, proposalTally)
+	}
+	return pollTally
+}
+
+// ranksOf deliberates, and returns the ranks of the proposals in the order of the tally.
+func ranksOf(deliberator judgment.DeliberatorInterface, pollTally *judgment.PollTally) (_ []int, err error) {
+	result, err := deliberator.Deliberate(pollTally)
+	if nil != err {
+		return nil, err
+	}
+	if len(result.Proposals) != len(pollTally.Proposals) {
+		return nil, errors.New("the result does not hold all the proposals")
+	}
+	ranks := make([]int, 0, len(result.Proposals))
+	for _, proposalResult := range result.Proposals {
+		ranks = append(ranks, proposalResult.Rank)
+	}
+	return ranks, nil
+}
@@ -0,0 +1,57 @@
+package reference
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDifferential(t *testing.T) {
+	disagreements := Differential(&Deliberator{}, &judgment.MajorityJudgment{}, &DifferentialOptions{AmountOfTrials: 3000, Seed: 1})
+	for _, disagreement := range disagreements {
+		t.Error(disagreement)
+	}
+
+	disagreements = Differential(&Deliberator{}, &judgment.MajorityJudgment{}, &DifferentialOptions{
+		AmountOfTrials:       50,
+		MaxAmountOfProposals: 3,
+		MaxAmountOfGrades:    7,
+		MaxAmountOfJudges:    1000,
+	})
+	assert.Empty(t, disagreements, "Both deliberators should agree on bigger polls")
+}
+
+// favoringDeliberator always ranks the first proposal first.
+type favoringDeliberator struct{}
+
+func (deliberator *favoringDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	result, err := (&judgment.MajorityJudgment{}).Deliberate(tally)
+	if nil == err && len(result.Proposals) > 0 {
+		result.Proposals[0].Rank = 1
+	}
+	return result, err
+}
+
+// failingDeliberator always fails.
+type failingDeliberator struct{}
+
+func (deliberator *failingDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	return nil, judgment.ErrTooManyJudgments
+}
+
+func TestDifferential_Disagreements(t *testing.T) {
+	disagreements := Differential(&Deliberator{}, &favoringDeliberator{}, &DifferentialOptions{AmountOfTrials: 100})
+	assert.NotEmpty(t, disagreements, "Favoritism should be caught")
+	assert.Contains(t, disagreements[0].String(), "ranks")
+	assert.Equal(t, 1, disagreements[0].Candidate[0])
+	assert.NotEqual(t, 1, disagreements[0].Reference[0])
+
+	again := Differential(&Deliberator{}, &favoringDeliberator{}, &DifferentialOptions{AmountOfTrials: 100})
+	assert.Equal(t, disagreements, again, "Checks should be replayable")
+
+	disagreements = Differential(&Deliberator{}, &failingDeliberator{}, &DifferentialOptions{AmountOfTrials: 10})
+	assert.Len(t, disagreements, 10)
+	assert.True(t, strings.HasPrefix(disagreements[0].Reason, "only the candidate failed"))
+	assert.Nil(t, disagreements[0].Candidate)
+}
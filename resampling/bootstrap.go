@@ -0,0 +1,273 @@
+// Package resampling estimates how much a poll result owes to chance, by bootstrapping its judgments.
+//
+// Each replicate draws as many judgments as the poll received, with replacement, deliberates them,
+// and the replicates are summed up into rank distributions and confidence intervals:
+//
+//	estimate, err := (&Bootstrap{AmountOfReplicates: 1000, Seed: 42}).ResampleTally(pollTally)
+//	fmt.Println(estimate.Proposals[0].WinProbability)
+package resampling
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Defaults of the Bootstrap
+const (
+	DefaultAmountOfReplicates = 1000
+	DefaultConfidence         = 0.95
+)
+
+// Bootstrap resamples polls.  Its zero value is usable.
+// Replicate r draws from its own source seeded with Seed + r,
+// so that estimates do not depend on the amount of workers.
+type Bootstrap struct {
+	Deliberator        judgment.DeliberatorInterface // defaults to MajorityJudgment
+	Balancing          *judgment.BalancingStrategy   // applied to the poll and to each replicate
+	AmountOfReplicates int                           // defaults to DefaultAmountOfReplicates
+	Confidence         float64                       // level of the intervals, defaults to DefaultConfidence
+	Seed               int64
+	Workers            int // replicates deliberated in parallel, defaults to runtime.NumCPU()
+}
+
+// Estimate is the outcome of a Bootstrap.
+type Estimate struct {
+	AmountOfReplicates int                   `json:"amountOfReplicates"`
+	Confidence         float64               `json:"confidence"`
+	Proposals          []*ProposalStatistics `json:"proposals"` // in the order of the proposals of the poll
+}
+
+// ProposalStatistics describes the distributions of the rank and of the median grade of a proposal over the replicates.
+type ProposalStatistics struct {
+	Index                  int       `json:"index"`
+	Rank                   int       `json:"rank"`                   // in the poll itself
+	MedianGrade            uint8     `json:"medianGrade"`            // in the poll itself
+	RankFrequencies        []float64 `json:"rankFrequencies"`        // share of the replicates giving rank r+1
+	WinProbability         float64   `json:"winProbability"`         // share of the replicates giving rank 1, ties included
+	RankLow                int       `json:"rankLow"`                // confidence interval on the rank
+	RankHigh               int       `json:"rankHigh"`               //
+	MedianGradeFrequencies []float64 `json:"medianGradeFrequencies"` // share of the replicates giving median grade g
+	MedianGradeLow         uint8     `json:"medianGradeLow"`         // confidence interval on the median grade
+	MedianGradeHigh        uint8     `json:"medianGradeHigh"`        //
+}
+
+// replicate holds what we keep of a single replicate.
+type replicate struct {
+	ranks        []int
+	medianGrades []uint8
+}
+
+// ResampleTally bootstraps a poll known only by its tally.
+// Without ballots, the judgments of each proposal are resampled independently of the other proposals,
+// which ignores any correlation between them ; prefer ResampleBallots when the ballots are available.
+func (bootstrap *Bootstrap) ResampleTally(pollTally *judgment.PollTally) (_ *Estimate, err error) {
+	if nil == pollTally {
+		return nil, errors.New("ResampleTally() needs a tally")
+	}
+	return bootstrap.run(pollTally, func(random *rand.Rand) (*judgment.PollTally, error) {
+		resampled := &judgment.PollTally{
+			AmountOfJudges: pollTally.AmountOfJudges,
+			Proposals:      make([]*judgment.ProposalTally, 0, len(pollTally.Proposals)),
+		}
+		for _, proposalTally := range pollTally.Proposals {
+			resampled.Proposals = append(resampled.Proposals, resampleProposal(random, proposalTally))
+		}
+		return resampled, nil
+	})
+}
+
+// ResampleBallots bootstraps a poll from its ballots, resampling judges rather than judgments.
+func (bootstrap *Bootstrap) ResampleBallots(
+	ballots []judgment.Ballot,
+	amountOfProposals int,
+	amountOfGrades int,
+) (_ *Estimate, err error) {
+	pollTally, err := judgment.TallyBallots(ballots, amountOfProposals, amountOfGrades)
+	if nil != err {
+		return nil, err
+	}
+	return bootstrap.run(pollTally, func(random *rand.Rand) (*judgment.PollTally, error) {
+		resampled := make([]judgment.Ballot, 0, len(ballots))
+		for range ballots {
+			resampled = append(resampled, ballots[random.Intn(len(ballots))])
+		}
+		return judgment.TallyBallots(resampled, amountOfProposals, amountOfGrades)
+	})
+}
+
+// run deliberates the poll, then the replicates made by resample, and sums them up.
+func (bootstrap *Bootstrap) run(
+	pollTally *judgment.PollTally,
+	resample func(random *rand.Rand) (*judgment.PollTally, error),
+) (_ *Estimate, err error) {
+	amountOfReplicates := bootstrap.AmountOfReplicates
+	if 0 == amountOfReplicates {
+		amountOfReplicates = DefaultAmountOfReplicates
+	}
+	if amountOfReplicates < 0 {
+		return nil, errors.New("the amount of replicates cannot be negative")
+	}
+	confidence := bootstrap.Confidence
+	if 0 == confidence {
+		confidence = DefaultConfidence
+	}
+	if confidence < 0 || confidence >= 1 {
+		return nil, fmt.Errorf("the confidence must be between 0 and 1, not %g", confidence)
+	}
+	workers := bootstrap.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	original, err := bootstrap.deliberate(pollTally.Copy())
+	if nil != err {
+		return nil, err
+	}
+
+	replicates := make([]*replicate, amountOfReplicates)
+	errs := make([]error, amountOfReplicates)
+	indices := make(chan int)
+	wait := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for index := range indices {
+				random := rand.New(rand.NewSource(bootstrap.Seed + int64(index)))
+				resampled, err := resample(random)
+				if nil == err {
+					replicates[index], err = bootstrap.deliberate(resampled)
+				}
+				errs[index] = err
+			}
+		}()
+	}
+	for index := 0; index < amountOfReplicates; index++ {
+		indices <- index
+	}
+	close(indices)
+	wait.Wait()
+	for index, err := range errs {
+		if nil != err {
+			return nil, fmt.Errorf("replicate #%d: %w", index, err)
+		}
+	}
+
+	return summarize(pollTally, original, replicates, confidence), nil
+}
+
+// deliberate balances the tally, which it mutates, and keeps the ranks and median grades.
+func (bootstrap *Bootstrap) deliberate(pollTally *judgment.PollTally) (_ *replicate, err error) {
+	if err = pollTally.Balance(bootstrap.Balancing); nil != err {
+		return nil, err
+	}
+	kept := &replicate{
+		ranks:        make([]int, 0, len(pollTally.Proposals)),
+		medianGrades: make([]uint8, 0, len(pollTally.Proposals)),
+	}
+	// Deliberators may mutate the tally, so we analyze it first.
+	for _, proposalTally := range pollTally.Proposals {
+		kept.medianGrades = append(kept.medianGrades, proposalTally.Analyze().MedianGrade)
+	}
+
+	deliberator := bootstrap.Deliberator
+	if nil == deliberator {
+		deliberator = &judgment.MajorityJudgment{}
+	}
+	result, err := deliberator.Deliberate(pollTally)
+	if nil != err {
+		return nil, err
+	}
+	for _, proposalResult := range result.Proposals {
+		kept.ranks = append(kept.ranks, proposalResult.Rank)
+	}
+	return kept, nil
+}
+
+// summarize the replicates into frequencies and intervals.
+func summarize(pollTally *judgment.PollTally, original *replicate, replicates []*replicate, confidence float64) *Estimate {
+	estimate := &Estimate{
+		AmountOfReplicates: len(replicates),
+		Confidence:         confidence,
+		Proposals:          make([]*ProposalStatistics, 0, len(pollTally.Proposals)),
+	}
+	amountOfProposals := len(pollTally.Proposals)
+	for proposalIndex, proposalTally := range pollTally.Proposals {
+		statistics := &ProposalStatistics{
+			Index:                  proposalIndex,
+			Rank:                   original.ranks[proposalIndex],
+			MedianGrade:            original.medianGrades[proposalIndex],
+			RankFrequencies:        make([]float64, amountOfProposals),
+			MedianGradeFrequencies: make([]float64, len(proposalTally.Tally)),
+		}
+		for _, kept := range replicates {
+			statistics.RankFrequencies[kept.ranks[proposalIndex]-1]++
+			statistics.MedianGradeFrequencies[kept.medianGrades[proposalIndex]]++
+		}
+		if len(replicates) > 0 {
+			for rank := range statistics.RankFrequencies {
+				statistics.RankFrequencies[rank] /= float64(len(replicates))
+			}
+			for grade := range statistics.MedianGradeFrequencies {
+				statistics.MedianGradeFrequencies[grade] /= float64(len(replicates))
+			}
+			statistics.WinProbability = statistics.RankFrequencies[0]
+
+			low, high := interval(statistics.RankFrequencies, confidence)
+			statistics.RankLow, statistics.RankHigh = low+1, high+1
+			low, high = interval(statistics.MedianGradeFrequencies, confidence)
+			statistics.MedianGradeLow, statistics.MedianGradeHigh = uint8(low), uint8(high)
+		} else {
+			statistics.RankLow, statistics.RankHigh = statistics.Rank, statistics.Rank
+			statistics.MedianGradeLow, statistics.MedianGradeHigh = statistics.MedianGrade, statistics.MedianGrade
+		}
+		estimate.Proposals = append(estimate.Proposals, statistics)
+	}
+	return estimate
+}
+
+// interval returns the percentile interval holding the confidence share of a discrete distribution,
+// as indices in its frequencies.
+func interval(frequencies []float64, confidence float64) (low int, high int) {
+	tail := (1 - confidence) / 2
+	// Tolerate the rounding errors of the sums, so that an exact quantile is not missed.
+	const epsilon = 1e-9
+	cumulated := 0.0
+	low, high = -1, len(frequencies)-1
+	for index, frequency := range frequencies {
+		cumulated += frequency
+		if -1 == low && cumulated > tail+epsilon {
+			low = index
+		}
+		if cumulated >= 1-tail-epsilon {
+			high = index
+			break
+		}
+	}
+	if -1 == low {
+		low = high
+	}
+	return low, high
+}
+
+// resampleProposal draws as many judgments as the proposal received, from the distribution of its grades.
+func resampleProposal(random *rand.Rand, proposalTally *judgment.ProposalTally) *judgment.ProposalTally {
+	resampled := &judgment.ProposalTally{Tally: make([]uint64, len(proposalTally.Tally))}
+	cumulated := make([]uint64, 0, len(proposalTally.Tally))
+	total := uint64(0)
+	for _, gradeTally := range proposalTally.Tally {
+		total += gradeTally
+		cumulated = append(cumulated, total)
+	}
+	for drawn := uint64(0); drawn < total; drawn++ {
+		pick := uint64(random.Int63n(int64(total)))
+		grade := sort.Search(len(cumulated), func(i int) bool { return pick < cumulated[i] })
+		resampled.Tally[grade]++
+	}
+	return resampled
+}
@@ -0,0 +1,110 @@
+package resampling
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBootstrap_ResampleTally(t *testing.T) {
+	pollTally := &judgment.PollTally{
+		Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{0, 0, 10, 90}}, // a clear winner
+			{Tally: []uint64{30, 30, 40, 0}},
+			{Tally: []uint64{30, 31, 39, 0}}, // nearly the same as the previous one
+		},
+	}
+	bootstrap := &Bootstrap{AmountOfReplicates: 200, Seed: 7, Workers: 3}
+	estimate, err := bootstrap.ResampleTally(pollTally)
+	assert.NoError(t, err, "Bootstrap should succeed")
+	assert.Equal(t, 200, estimate.AmountOfReplicates)
+	assert.Equal(t, DefaultConfidence, estimate.Confidence)
+	assert.Len(t, estimate.Proposals, 3)
+
+	winner := estimate.Proposals[0]
+	assert.Equal(t, 1, winner.Rank)
+	assert.Equal(t, uint8(3), winner.MedianGrade)
+	assert.Equal(t, 1.0, winner.WinProbability)
+	assert.Equal(t, 1, winner.RankLow)
+	assert.Equal(t, 1, winner.RankHigh)
+	assert.Equal(t, uint8(3), winner.MedianGradeLow)
+	assert.Equal(t, uint8(3), winner.MedianGradeHigh)
+
+	for _, statistics := range estimate.Proposals[1:] {
+		assert.Equal(t, 0.0, statistics.WinProbability)
+		assert.Equal(t, 2, statistics.RankLow, "Close proposals should swap ranks")
+		assert.Equal(t, 3, statistics.RankHigh, "Close proposals should swap ranks")
+		assert.True(t, statistics.MedianGradeLow <= statistics.MedianGrade)
+		assert.True(t, statistics.MedianGrade <= statistics.MedianGradeHigh)
+		total := 0.0
+		for _, frequency := range statistics.RankFrequencies {
+			total += frequency
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	}
+	assert.True(t, estimate.Proposals[1].RankFrequencies[1]+estimate.Proposals[2].RankFrequencies[1] >= 1, "Either one should rank second, or both when tied")
+
+	bootstrap.Workers = 1
+	again, err := bootstrap.ResampleTally(pollTally)
+	assert.NoError(t, err)
+	assert.Equal(t, estimate, again, "Estimates should not depend on the amount of workers")
+	assert.Equal(t, []uint64{0, 0, 10, 90}, pollTally.Proposals[0].Tally, "The tally should be left intact")
+}
+
+func TestBootstrap_ResampleTally_Balancing(t *testing.T) {
+	pollTally := &judgment.PollTally{
+		AmountOfJudges: 10,
+		Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{0, 0, 4}},
+			{Tally: []uint64{0, 3, 0}},
+		},
+	}
+	_, err := (&Bootstrap{AmountOfReplicates: 10}).ResampleTally(pollTally)
+	assert.ErrorIs(t, err, judgment.ErrUnbalancedTally)
+
+	bootstrap := &Bootstrap{
+		AmountOfReplicates: 10,
+		Balancing:          &judgment.BalancingStrategy{Kind: judgment.BalancingStatic, DefaultGrade: 0},
+	}
+	estimate, err := bootstrap.ResampleTally(pollTally)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0), estimate.Proposals[0].MedianGrade, "Balancing should apply to the poll")
+	assert.Equal(t, []float64{1, 0, 0}, estimate.Proposals[0].MedianGradeFrequencies, "Balancing should apply to the replicates")
+}
+
+func TestBootstrap_ResampleBallots(t *testing.T) {
+	ballots := []judgment.Ballot{{2, 0}, {2, 1}, {1, 2}, {0, 2}, {2, 1}}
+	estimate, err := (&Bootstrap{AmountOfReplicates: 300, Seed: 1}).ResampleBallots(ballots, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, estimate.Proposals[0].Rank)
+	assert.Equal(t, 2, estimate.Proposals[1].Rank)
+	assert.True(t, estimate.Proposals[0].WinProbability > estimate.Proposals[1].WinProbability)
+	assert.InDelta(t, 1.0, estimate.Proposals[0].RankFrequencies[0]+estimate.Proposals[0].RankFrequencies[1], 1e-9)
+
+	_, err = (&Bootstrap{}).ResampleBallots([]judgment.Ballot{{0, 1, 2}}, 2, 3)
+	assert.ErrorIs(t, err, judgment.ErrMishapedTally)
+}
+
+func TestBootstrap_Failures(t *testing.T) {
+	pollTally := &judgment.PollTally{Proposals: []*judgment.ProposalTally{{Tally: []uint64{1, 2}}}}
+	_, err := (&Bootstrap{}).ResampleTally(nil)
+	assert.Error(t, err)
+	_, err = (&Bootstrap{AmountOfReplicates: -1}).ResampleTally(pollTally)
+	assert.Error(t, err)
+	_, err = (&Bootstrap{Confidence: 1}).ResampleTally(pollTally)
+	assert.Error(t, err)
+	_, err = (&Bootstrap{Balancing: &judgment.BalancingStrategy{Kind: "mean"}}).ResampleTally(pollTally)
+	assert.Error(t, err)
+}
+
+func TestInterval(t *testing.T) {
+	low, high := interval([]float64{0.01, 0.5, 0.48, 0.01}, 0.95)
+	assert.Equal(t, 1, low)
+	assert.Equal(t, 2, high)
+	low, high = interval([]float64{0, 1, 0}, 0.95)
+	assert.Equal(t, 1, low)
+	assert.Equal(t, 1, high)
+	low, high = interval([]float64{0.1, 0.2, 0.3, 0.4}, 0.5)
+	assert.Equal(t, 1, low)
+	assert.Equal(t, 3, high)
+}
@@ -0,0 +1,89 @@
+package simulation
+
+import (
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"sort"
+)
+
+// MeanScore ranks proposals by the mean of their grades, like range voting.
+// It implements judgment.DeliberatorInterface, to be compared with MajorityJudgment.
+type MeanScore struct{}
+
+// Deliberate is part of the DeliberatorInterface
+func (deliberator *MeanScore) Deliberate(tally *judgment.PollTally) (_ *judgment.PollResult, err error) {
+	// With balanced tallies, comparing sums is comparing means.
+	return deliberateByPoints(tally, func(proposalTally *judgment.ProposalTally) uint64 {
+		sum := uint64(0)
+		for grade, amount := range proposalTally.Tally {
+			sum += uint64(grade) * amount
+		}
+		return sum
+	})
+}
+
+// Approval ranks proposals by their amount of judgments in the upper half of the grades,
+// as if judges approved of those proposals.  With an odd amount of grades, the middle grade does not approve.
+// It implements judgment.DeliberatorInterface, to be compared with MajorityJudgment.
+type Approval struct{}
+
+// Deliberate is part of the DeliberatorInterface
+func (deliberator *Approval) Deliberate(tally *judgment.PollTally) (_ *judgment.PollResult, err error) {
+	return deliberateByPoints(tally, func(proposalTally *judgment.ProposalTally) uint64 {
+		approvals := uint64(0)
+		amountOfGrades := len(proposalTally.Tally)
+		for grade, amount := range proposalTally.Tally {
+			if 2*grade >= amountOfGrades {
+				approvals += amount
+			}
+		}
+		return approvals
+	})
+}
+
+// deliberateByPoints checks the tally like MajorityJudgment does, and ranks proposals by their points.
+func deliberateByPoints(
+	tally *judgment.PollTally,
+	points func(proposalTally *judgment.ProposalTally) uint64,
+) (_ *judgment.PollResult, err error) {
+	if 0 == len(tally.Proposals) {
+		return &judgment.PollResult{Proposals: judgment.ProposalsResults{}}, nil
+	}
+
+	amountOfGrades := len(tally.Proposals[0].Tally)
+	amountOfJudges := tally.Proposals[0].CountJudgments()
+	for proposalIndex, proposalTally := range tally.Proposals {
+		if amountOfGrades != len(proposalTally.Tally) {
+			return nil, fmt.Errorf("%w: proposal #%d", judgment.ErrMishapedTally, proposalIndex)
+		}
+		if amountOfJudges != proposalTally.CountJudgments() {
+			return nil, fmt.Errorf("%w: proposal #%d", judgment.ErrUnbalancedTally, proposalIndex)
+		}
+	}
+
+	proposalsResults := make(judgment.ProposalsResults, 0, len(tally.Proposals))
+	proposalsResultsSorted := make(judgment.ProposalsResults, 0, len(tally.Proposals))
+	for proposalIndex, proposalTally := range tally.Proposals {
+		proposalResult := &judgment.ProposalResult{
+			Index:    proposalIndex,
+			Score:    fmt.Sprintf("%020d", points(proposalTally)),
+			Analysis: proposalTally.Analyze(),
+			Tally:    proposalTally,
+		}
+		proposalsResults = append(proposalsResults, proposalResult)
+		proposalsResultsSorted = append(proposalsResultsSorted, proposalResult)
+	}
+
+	sort.Stable(sort.Reverse(proposalsResultsSorted))
+	for proposalIndex, proposalResult := range proposalsResultsSorted {
+		proposalResult.Rank = proposalIndex + 1
+		if proposalIndex > 0 && proposalsResultsSorted[proposalIndex-1].Score == proposalResult.Score {
+			proposalResult.Rank = proposalsResultsSorted[proposalIndex-1].Rank
+		}
+	}
+
+	return &judgment.PollResult{
+		Proposals:       proposalsResults,
+		ProposalsSorted: proposalsResultsSorted,
+	}, nil
+}
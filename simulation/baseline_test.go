@@ -0,0 +1,56 @@
+package simulation
+
+import (
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMeanScore_Deliberate(t *testing.T) {
+	pollTally := &judgment.PollTally{Proposals: []*judgment.ProposalTally{
+		{Tally: []uint64{0, 4, 0}}, // mean 1
+		{Tally: []uint64{1, 0, 3}}, // mean 1.5
+		{Tally: []uint64{2, 0, 2}}, // mean 1
+	}}
+	result, err := (&MeanScore{}).Deliberate(pollTally)
+	assert.NoError(t, err, "Deliberation should succeed")
+	assert.Equal(t, []int{2, 1, 2}, []int{result.Proposals[0].Rank, result.Proposals[1].Rank, result.Proposals[2].Rank})
+	assert.Equal(t, 1, result.ProposalsSorted[0].Index)
+}
+
+func TestApproval_Deliberate(t *testing.T) {
+	pollTally := &judgment.PollTally{Proposals: []*judgment.ProposalTally{
+		{Tally: []uint64{0, 4, 0}}, // the middle grade does not approve
+		{Tally: []uint64{1, 0, 3}},
+		{Tally: []uint64{2, 0, 2}},
+	}}
+	result, err := (&Approval{}).Deliberate(pollTally)
+	assert.NoError(t, err, "Deliberation should succeed")
+	assert.Equal(t, []int{3, 1, 2}, []int{result.Proposals[0].Rank, result.Proposals[1].Rank, result.Proposals[2].Rank})
+
+	even := &judgment.PollTally{Proposals: []*judgment.ProposalTally{
+		{Tally: []uint64{0, 2, 0, 0}},
+		{Tally: []uint64{1, 0, 1, 0}},
+	}}
+	result, err = (&Approval{}).Deliberate(even)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ProposalsSorted[0].Index)
+}
+
+func TestDeliberateByPoints_Failures(t *testing.T) {
+	for _, deliberator := range []judgment.DeliberatorInterface{&MeanScore{}, &Approval{}} {
+		_, err := deliberator.Deliberate(&judgment.PollTally{Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{1, 2}}, {Tally: []uint64{3}},
+		}})
+		assert.True(t, errors.Is(err, judgment.ErrMishapedTally))
+		_, err = deliberator.Deliberate(&judgment.PollTally{Proposals: []*judgment.ProposalTally{
+			{Tally: []uint64{1, 2}}, {Tally: []uint64{1, 1}},
+		}})
+		assert.True(t, errors.Is(err, judgment.ErrUnbalancedTally))
+
+		result, err := deliberator.Deliberate(&judgment.PollTally{})
+		assert.NoError(t, err)
+		assert.Empty(t, result.Proposals)
+	}
+}
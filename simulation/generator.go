@@ -0,0 +1,238 @@
+// Package simulation generates synthetic polls, and compares deliberators over many of them.
+//
+// Generators emit ballots from a seeded source of randomness, so that experiments can be replayed:
+//
+//	random := rand.New(rand.NewSource(42))
+//	ballots, pollTally, err := GeneratePoll(&Spatial{Dimensions: 2}, random, 100, 5, 7)
+package simulation
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"math"
+	"math/rand"
+)
+
+// Generator makes up the ballots of a poll.
+// Implementations draw all their randomness from random, and grade all the proposals on each ballot.
+type Generator interface {
+	GenerateBallots(random *rand.Rand, amountOfJudges int, amountOfProposals int, amountOfGrades int) []judgment.Ballot
+}
+
+// GeneratePoll generates ballots, and their tally.
+func GeneratePoll(
+	generator Generator,
+	random *rand.Rand,
+	amountOfJudges int,
+	amountOfProposals int,
+	amountOfGrades int,
+) (_ []judgment.Ballot, _ *judgment.PollTally, err error) {
+	ballots := generator.GenerateBallots(random, amountOfJudges, amountOfProposals, amountOfGrades)
+	pollTally, err := judgment.TallyBallots(ballots, amountOfProposals, amountOfGrades)
+	if nil != err {
+		return nil, nil, err
+	}
+	return ballots, pollTally, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ImpartialCulture gives each judgment a grade drawn uniformly, independently of everything else.
+// This is the usual worst case, with many close results.
+type ImpartialCulture struct{}
+
+// GenerateBallots is part of the Generator interface
+func (generator *ImpartialCulture) GenerateBallots(
+	random *rand.Rand,
+	amountOfJudges int,
+	amountOfProposals int,
+	amountOfGrades int,
+) []judgment.Ballot {
+	ballots := make([]judgment.Ballot, 0, amountOfJudges)
+	for judgeIndex := 0; judgeIndex < amountOfJudges; judgeIndex++ {
+		ballot := make(judgment.Ballot, amountOfProposals)
+		for proposalIndex := range ballot {
+			ballot[proposalIndex] = uint8(random.Intn(amountOfGrades))
+		}
+		ballots = append(ballots, ballot)
+	}
+	return ballots
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Spatial places judges and proposals uniformly in a unit (hyper)cube of opinions,
+// and judges grade proposals according to how close they are.
+// A proposal at the position of the judge gets the best grade,
+// and a proposal farther than Radius gets the worst grade.
+type Spatial struct {
+	Dimensions int     // 1 for a left-right axis, 2 for a political compass ; defaults to 1
+	Radius     float64 // defaults to the diagonal of the cube, sqrt(Dimensions)
+}
+
+// GenerateBallots is part of the Generator interface
+func (generator *Spatial) GenerateBallots(
+	random *rand.Rand,
+	amountOfJudges int,
+	amountOfProposals int,
+	amountOfGrades int,
+) []judgment.Ballot {
+	dimensions := generator.Dimensions
+	if dimensions < 1 {
+		dimensions = 1
+	}
+	radius := generator.Radius
+	if radius <= 0 {
+		radius = math.Sqrt(float64(dimensions))
+	}
+
+	proposals := make([][]float64, 0, amountOfProposals)
+	for proposalIndex := 0; proposalIndex < amountOfProposals; proposalIndex++ {
+		proposals = append(proposals, randomPosition(random, dimensions))
+	}
+
+	ballots := make([]judgment.Ballot, 0, amountOfJudges)
+	for judgeIndex := 0; judgeIndex < amountOfJudges; judgeIndex++ {
+		judge := randomPosition(random, dimensions)
+		ballot := make(judgment.Ballot, amountOfProposals)
+		for proposalIndex, proposal := range proposals {
+			utility := 1 - distance(judge, proposal)/radius
+			ballot[proposalIndex] = gradeOfUtility(utility, amountOfGrades)
+		}
+		ballots = append(ballots, ballot)
+	}
+	return ballots
+}
+
+func randomPosition(random *rand.Rand, dimensions int) []float64 {
+	position := make([]float64, dimensions)
+	for dimension := range position {
+		position[dimension] = random.Float64()
+	}
+	return position
+}
+
+func distance(a []float64, b []float64) float64 {
+	sum := 0.0
+	for dimension := range a {
+		sum += (a[dimension] - b[dimension]) * (a[dimension] - b[dimension])
+	}
+	return math.Sqrt(sum)
+}
+
+// gradeOfUtility maps a utility in [0, 1] onto grades of equal width ; it is clamped.
+func gradeOfUtility(utility float64, amountOfGrades int) uint8 {
+	grade := int(math.Floor(utility * float64(amountOfGrades)))
+	if grade >= amountOfGrades {
+		grade = amountOfGrades - 1
+	}
+	if grade < 0 {
+		grade = 0
+	}
+	return uint8(grade)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Polarized splits judges and proposals into two camps.
+// Cohesive judges grade the proposals of their camp in the upper half of the grades,
+// and the proposals of the other camp in the lower half.
+// The other judges grade like in an ImpartialCulture.
+type Polarized struct {
+	Share    float64 // share of the judges in the first camp ; defaults to 0.5
+	Cohesion float64 // probability that a judge follows their camp ; defaults to 0.8
+}
+
+// GenerateBallots is part of the Generator interface
+func (generator *Polarized) GenerateBallots(
+	random *rand.Rand,
+	amountOfJudges int,
+	amountOfProposals int,
+	amountOfGrades int,
+) []judgment.Ballot {
+	share := generator.Share
+	if share <= 0 {
+		share = 0.5
+	}
+	cohesion := generator.Cohesion
+	if cohesion <= 0 {
+		cohesion = 0.8
+	}
+
+	camps := make([]bool, amountOfProposals)
+	for proposalIndex := range camps {
+		camps[proposalIndex] = random.Float64() < 0.5
+	}
+
+	// With an odd amount of grades, the middle grade belongs to both halves.
+	lowerHalf := (amountOfGrades + 1) / 2
+	upperHalfStart := amountOfGrades / 2
+
+	ballots := make([]judgment.Ballot, 0, amountOfJudges)
+	for judgeIndex := 0; judgeIndex < amountOfJudges; judgeIndex++ {
+		camp := random.Float64() < share
+		cohesive := random.Float64() < cohesion
+		ballot := make(judgment.Ballot, amountOfProposals)
+		for proposalIndex := range ballot {
+			switch {
+			case !cohesive:
+				ballot[proposalIndex] = uint8(random.Intn(amountOfGrades))
+			case camp == camps[proposalIndex]:
+				ballot[proposalIndex] = uint8(upperHalfStart + random.Intn(amountOfGrades-upperHalfStart))
+			default:
+				ballot[proposalIndex] = uint8(random.Intn(lowerHalf))
+			}
+		}
+		ballots = append(ballots, ballot)
+	}
+	return ballots
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Strategic makes a share of the judges of another generator exaggerate:
+// they give the best grade to the proposals they graded above the mean of their ballot,
+// and the worst grade to the proposals they graded below it.
+type Strategic struct {
+	Base  Generator // the sincere electorate ; defaults to an ImpartialCulture
+	Share float64   // share of exaggerating judges, from 0 to 1
+}
+
+// GenerateBallots is part of the Generator interface
+func (generator *Strategic) GenerateBallots(
+	random *rand.Rand,
+	amountOfJudges int,
+	amountOfProposals int,
+	amountOfGrades int,
+) []judgment.Ballot {
+	base := generator.Base
+	if nil == base {
+		base = &ImpartialCulture{}
+	}
+
+	ballots := base.GenerateBallots(random, amountOfJudges, amountOfProposals, amountOfGrades)
+	for _, ballot := range ballots {
+		if random.Float64() < generator.Share {
+			exaggerate(ballot, amountOfGrades)
+		}
+	}
+	return ballots
+}
+
+func exaggerate(ballot judgment.Ballot, amountOfGrades int) {
+	if 0 == len(ballot) {
+		return
+	}
+	sum := 0
+	for _, grade := range ballot {
+		sum += int(grade)
+	}
+	// Compare grade * len against sum to stay within integers.
+	for proposalIndex, grade := range ballot {
+		switch {
+		case int(grade)*len(ballot) > sum:
+			ballot[proposalIndex] = uint8(amountOfGrades - 1)
+		case int(grade)*len(ballot) < sum:
+			ballot[proposalIndex] = 0
+		}
+	}
+}
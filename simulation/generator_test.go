@@ -0,0 +1,75 @@
+package simulation
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGenerators_Shape(t *testing.T) {
+	generators := map[string]Generator{
+		"Impartial culture": &ImpartialCulture{},
+		"1-D spatial":       &Spatial{},
+		"2-D spatial":       &Spatial{Dimensions: 2, Radius: 0.5},
+		"Polarized":         &Polarized{Share: 0.3, Cohesion: 0.9},
+		"Strategic":         &Strategic{Base: &Spatial{}, Share: 0.5},
+	}
+	for name, generator := range generators {
+		t.Run(name, func(t *testing.T) {
+			for _, amountOfGrades := range []int{1, 2, 5, 256} {
+				ballots, pollTally, err := GeneratePoll(generator, rand.New(rand.NewSource(1)), 50, 4, amountOfGrades)
+				assert.NoError(t, err, "Ballots should be valid")
+				assert.Len(t, ballots, 50)
+				assert.Len(t, pollTally.Proposals, 4)
+				assert.Equal(t, uint64(50), pollTally.AmountOfJudges)
+				for _, proposalTally := range pollTally.Proposals {
+					assert.Equal(t, uint64(50), proposalTally.CountJudgments())
+				}
+			}
+		})
+	}
+}
+
+func TestGenerators_Seeded(t *testing.T) {
+	for _, generator := range []Generator{&ImpartialCulture{}, &Spatial{Dimensions: 3}, &Polarized{}, &Strategic{Share: 1}} {
+		first := generator.GenerateBallots(rand.New(rand.NewSource(42)), 20, 3, 7)
+		second := generator.GenerateBallots(rand.New(rand.NewSource(42)), 20, 3, 7)
+		assert.Equal(t, first, second, "Same seed should give same ballots")
+	}
+}
+
+func TestSpatial_Grades(t *testing.T) {
+	assert.Equal(t, uint8(4), gradeOfUtility(1, 5))
+	assert.Equal(t, uint8(4), gradeOfUtility(0.8, 5))
+	assert.Equal(t, uint8(3), gradeOfUtility(0.79, 5))
+	assert.Equal(t, uint8(0), gradeOfUtility(0.1, 5))
+	assert.Equal(t, uint8(0), gradeOfUtility(-3, 5), "Utility should be clamped")
+	assert.InDelta(t, 5.0, distance([]float64{0, 0}, []float64{3, 4}), 1e-9)
+}
+
+func TestPolarized_Camps(t *testing.T) {
+	ballots := (&Polarized{Cohesion: 1}).GenerateBallots(rand.New(rand.NewSource(7)), 200, 6, 4)
+	// With 4 grades, cohesive judges grade 2 or 3 for their camp, and 0 or 1 against it.
+	// Whatever their camp, two proposals are thus either always on the same side, or never.
+	for a := 0; a < 6; a++ {
+		for b := 0; b < 6; b++ {
+			sameSide := (ballots[0][a] >= 2) == (ballots[0][b] >= 2)
+			for _, ballot := range ballots {
+				assert.Equal(t, sameSide, (ballot[a] >= 2) == (ballot[b] >= 2))
+			}
+		}
+	}
+}
+
+func TestExaggerate(t *testing.T) {
+	ballot := judgment.Ballot{1, 2, 3, 2}
+	exaggerate(ballot, 5)
+	assert.Equal(t, judgment.Ballot{0, 2, 4, 2}, ballot, "Grades at the mean should stay")
+
+	flat := judgment.Ballot{3, 3}
+	exaggerate(flat, 5)
+	assert.Equal(t, judgment.Ballot{3, 3}, flat)
+
+	exaggerate(judgment.Ballot{}, 5)
+}
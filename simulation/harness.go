@@ -0,0 +1,199 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"text/tabwriter"
+)
+
+// Contender is a deliberator taking part in an Experiment.
+type Contender struct {
+	Name        string
+	Deliberator judgment.DeliberatorInterface
+}
+
+// DefaultContenders returns MajorityJudgment, MeanScore and Approval.
+func DefaultContenders() []Contender {
+	return []Contender{
+		{Name: "Majority Judgment", Deliberator: &judgment.MajorityJudgment{}},
+		{Name: "Mean Score", Deliberator: &MeanScore{}},
+		{Name: "Approval", Deliberator: &Approval{}},
+	}
+}
+
+// Experiment runs its contenders over many polls made up by its generator.
+// Trial t uses the seed Seed + t, so that any trial can be replayed on its own.
+type Experiment struct {
+	Generator         Generator
+	Contenders        []Contender // defaults to DefaultContenders()
+	AmountOfTrials    int
+	AmountOfJudges    int
+	AmountOfProposals int
+	AmountOfGrades    int
+	Seed              int64
+}
+
+// ContenderStatistics sums up how a contender fared during an Experiment.
+type ContenderStatistics struct {
+	Name      string
+	Failures  int       // trials where the deliberation failed
+	Ties      int       // trials where several proposals shared the first rank
+	Wins      []int     // for each proposal, the trials where it ranked first, tied or not
+	Agreement []float64 // for each contender, the share of trials where both agreed on the winners
+}
+
+// Report is the outcome of an Experiment.
+type Report struct {
+	AmountOfTrials int
+	Contenders     []*ContenderStatistics
+}
+
+// Run the experiment.  Deliberation failures are counted, not returned.
+func (experiment *Experiment) Run() (_ *Report, err error) {
+	if nil == experiment.Generator {
+		return nil, errors.New("the experiment needs a generator")
+	}
+	if experiment.AmountOfTrials < 1 || experiment.AmountOfJudges < 1 || experiment.AmountOfProposals < 1 {
+		return nil, errors.New("the experiment needs at least one trial, judge and proposal")
+	}
+	// Deliberation counts the grades of a proposal in a uint8.
+	if experiment.AmountOfGrades < 1 || experiment.AmountOfGrades > math.MaxUint8 {
+		return nil, fmt.Errorf("the experiment needs between 1 and %d grades", math.MaxUint8)
+	}
+	contenders := experiment.Contenders
+	if 0 == len(contenders) {
+		contenders = DefaultContenders()
+	}
+
+	report := &Report{
+		AmountOfTrials: experiment.AmountOfTrials,
+		Contenders:     make([]*ContenderStatistics, 0, len(contenders)),
+	}
+	for _, contender := range contenders {
+		report.Contenders = append(report.Contenders, &ContenderStatistics{
+			Name:      contender.Name,
+			Wins:      make([]int, experiment.AmountOfProposals),
+			Agreement: make([]float64, len(contenders)),
+		})
+	}
+	agreements := make([][]int, len(contenders))
+	comparisons := make([][]int, len(contenders))
+	for contenderIndex := range contenders {
+		agreements[contenderIndex] = make([]int, len(contenders))
+		comparisons[contenderIndex] = make([]int, len(contenders))
+	}
+
+	for trial := 0; trial < experiment.AmountOfTrials; trial++ {
+		random := rand.New(rand.NewSource(experiment.Seed + int64(trial)))
+		_, pollTally, err := GeneratePoll(experiment.Generator, random,
+			experiment.AmountOfJudges, experiment.AmountOfProposals, experiment.AmountOfGrades)
+		if nil != err {
+			return nil, fmt.Errorf("trial #%d: %w", trial, err)
+		}
+
+		winners := make([][]int, len(contenders))
+		for contenderIndex, contender := range contenders {
+			statistics := report.Contenders[contenderIndex]
+			// Deliberators may mutate the tally, so each gets its own.
+			result, err := contender.Deliberator.Deliberate(pollTally.Copy())
+			if nil != err {
+				statistics.Failures++
+				continue
+			}
+			winners[contenderIndex] = winnersOf(result)
+			for _, winner := range winners[contenderIndex] {
+				statistics.Wins[winner]++
+			}
+			if len(winners[contenderIndex]) > 1 {
+				statistics.Ties++
+			}
+		}
+
+		for a := range contenders {
+			for b := range contenders {
+				if nil == winners[a] || nil == winners[b] {
+					continue
+				}
+				comparisons[a][b]++
+				if sameInts(winners[a], winners[b]) {
+					agreements[a][b]++
+				}
+			}
+		}
+	}
+
+	for a, statistics := range report.Contenders {
+		for b := range contenders {
+			if comparisons[a][b] > 0 {
+				statistics.Agreement[b] = float64(agreements[a][b]) / float64(comparisons[a][b])
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// WriteText writes the statistics and the agreement matrix of the report as plain text tables.
+func (report *Report) WriteText(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "%d trials\n\n", report.AmountOfTrials)
+
+	header := []string{"Contender", "Failures", "Ties"}
+	if len(report.Contenders) > 0 {
+		for proposalIndex := range report.Contenders[0].Wins {
+			header = append(header, fmt.Sprintf("Wins #%d", proposalIndex))
+		}
+	}
+	fmt.Fprintf(table, "%s\t\n", strings.Join(header, "\t"))
+	for _, statistics := range report.Contenders {
+		cells := []string{statistics.Name, fmt.Sprint(statistics.Failures), fmt.Sprint(statistics.Ties)}
+		for _, wins := range statistics.Wins {
+			cells = append(cells, fmt.Sprint(wins))
+		}
+		fmt.Fprintf(table, "%s\t\n", strings.Join(cells, "\t"))
+	}
+
+	fmt.Fprintln(table)
+	header = []string{"Agreement"}
+	for _, statistics := range report.Contenders {
+		header = append(header, statistics.Name)
+	}
+	fmt.Fprintf(table, "%s\t\n", strings.Join(header, "\t"))
+	for _, statistics := range report.Contenders {
+		cells := []string{statistics.Name}
+		for _, agreement := range statistics.Agreement {
+			cells = append(cells, fmt.Sprintf("%.1f%%", 100*agreement))
+		}
+		fmt.Fprintf(table, "%s\t\n", strings.Join(cells, "\t"))
+	}
+
+	return table.Flush()
+}
+
+// winnersOf returns the indices of the proposals ranked first, in increasing order.
+func winnersOf(result *judgment.PollResult) []int {
+	winners := []int{}
+	for _, proposalResult := range result.Proposals {
+		if 1 == proposalResult.Rank {
+			winners = append(winners, proposalResult.Index)
+		}
+	}
+	return winners
+}
+
+func sameInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
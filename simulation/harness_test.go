@@ -0,0 +1,77 @@
+package simulation
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// failingDeliberator always fails, to check that failures are counted.
+type failingDeliberator struct{}
+
+func (deliberator *failingDeliberator) Deliberate(tally *judgment.PollTally) (*judgment.PollResult, error) {
+	return nil, judgment.ErrTooManyJudgments
+}
+
+func TestExperiment_Run(t *testing.T) {
+	experiment := &Experiment{
+		Generator:         &ImpartialCulture{},
+		AmountOfTrials:    100,
+		AmountOfJudges:    25,
+		AmountOfProposals: 3,
+		AmountOfGrades:    5,
+		Seed:              1,
+	}
+	report, err := experiment.Run()
+	assert.NoError(t, err, "Experiment should run")
+	assert.Equal(t, 100, report.AmountOfTrials)
+	assert.Len(t, report.Contenders, 3)
+
+	for contenderIndex, statistics := range report.Contenders {
+		assert.Equal(t, 0, statistics.Failures)
+		totalWins := 0
+		for _, wins := range statistics.Wins {
+			totalWins += wins
+		}
+		assert.True(t, totalWins >= 100, "Each trial should have a winner")
+		assert.True(t, totalWins-100 >= statistics.Ties, "Each tie should count several winners")
+		assert.Equal(t, 1.0, statistics.Agreement[contenderIndex])
+		for otherIndex, agreement := range statistics.Agreement {
+			assert.Equal(t, agreement, report.Contenders[otherIndex].Agreement[contenderIndex], "Agreement should be symmetric")
+		}
+	}
+
+	again, err := experiment.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, report, again, "Experiments should be replayable")
+
+	b := &strings.Builder{}
+	assert.NoError(t, report.WriteText(b))
+	assert.Contains(t, b.String(), "100 trials")
+	assert.Contains(t, b.String(), "Majority Judgment")
+	assert.Contains(t, b.String(), "100.0%")
+}
+
+func TestExperiment_Failures(t *testing.T) {
+	report, err := (&Experiment{
+		Generator:         &Spatial{},
+		Contenders:        []Contender{{Name: "MJ", Deliberator: &judgment.MajorityJudgment{}}, {Name: "Failing", Deliberator: &failingDeliberator{}}},
+		AmountOfTrials:    10,
+		AmountOfJudges:    5,
+		AmountOfProposals: 2,
+		AmountOfGrades:    3,
+	}).Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 10, report.Contenders[1].Failures)
+	assert.Equal(t, 0.0, report.Contenders[0].Agreement[1], "Failed trials should not count as agreements")
+
+	for _, experiment := range []*Experiment{
+		{AmountOfTrials: 1, AmountOfJudges: 1, AmountOfProposals: 1, AmountOfGrades: 1},
+		{Generator: &ImpartialCulture{}, AmountOfJudges: 1, AmountOfProposals: 1, AmountOfGrades: 1},
+		{Generator: &ImpartialCulture{}, AmountOfTrials: 1, AmountOfJudges: 1, AmountOfProposals: 1, AmountOfGrades: 256},
+	} {
+		_, err := experiment.Run()
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,469 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Files of each poll, in a directory named after the poll
+const (
+	pollFileName       = "poll.json"
+	walFileName        = "judgments.wal"
+	checkpointFileName = "checkpoint.json"
+	resultFileName     = "result.json"
+	temporaryPrefix    = ".tmp-"
+)
+
+// defaultCheckpointInterval is the amount of appends between two checkpoints
+const defaultCheckpointInterval = 1000
+
+// FileStoreOptions tunes a FileStore.
+type FileStoreOptions struct {
+	CheckpointInterval int  // appends between two checkpoints of a tally ; defaults to 1000
+	NoSync             bool // skips fsync, which is faster but loses the crash safety ; for tests
+}
+
+// FileStore keeps each poll in its own directory, and survives crashes.
+//
+// Judgments are appended to a write-ahead log, one checksummed record per call to AppendJudgments,
+// synced before AppendJudgments returns.  Every CheckpointInterval appends, the tally is written
+// atomically along with the offset of the log it accounts for, so that restarts only replay the end of the log.
+// On restart, a torn record at the end of the log, left by a crash mid-write, is discarded,
+// whereas a corrupted whole record fails the opening of the store, since judgments after it were synced.
+type FileStore struct {
+	mutex     sync.Mutex
+	directory string
+	options   FileStoreOptions
+	polls     map[string]*filePoll
+	closed    bool
+}
+
+type filePoll struct {
+	directory              string
+	tally                  *judgment.PollTally
+	wal                    *os.File
+	walOffset              int64 // end of the last valid record
+	appendsSinceCheckpoint int
+}
+
+// pollMetadata is the content of poll.json
+type pollMetadata struct {
+	AmountOfProposals int `json:"amountOfProposals"`
+	AmountOfGrades    int `json:"amountOfGrades"`
+}
+
+// checkpoint is the content of checkpoint.json
+type checkpoint struct {
+	WALOffset int64               `json:"walOffset"` // the tally accounts for the log up to this offset
+	Tally     *judgment.PollTally `json:"tally"`
+}
+
+// OpenFileStore opens the store in directory, creating it if needed,
+// and rebuilds the tallies of its polls from their checkpoints and logs.
+// options may be nil.
+func OpenFileStore(directory string, options *FileStoreOptions) (_ *FileStore, err error) {
+	s := &FileStore{directory: directory, polls: map[string]*filePoll{}}
+	if nil != options {
+		s.options = *options
+	}
+	if s.options.CheckpointInterval <= 0 {
+		s.options.CheckpointInterval = defaultCheckpointInterval
+	}
+
+	if err := os.MkdirAll(directory, 0755); nil != err {
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(directory)
+	if nil != err {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), temporaryPrefix) {
+			// A poll whose creation crashed midway
+			if err := os.RemoveAll(filepath.Join(directory, entry.Name())); nil != err {
+				_ = s.Close()
+				return nil, err
+			}
+			continue
+		}
+		poll, err := s.recoverPoll(filepath.Join(directory, entry.Name()))
+		if nil != err {
+			_ = s.Close()
+			return nil, fmt.Errorf("cannot recover poll %s: %w", entry.Name(), err)
+		}
+		s.polls[entry.Name()] = poll
+	}
+	return s, nil
+}
+
+// CreatePoll is part of the Store interface.
+// The directory of the poll is prepared under a temporary name, then renamed, so that it appears whole.
+func (s *FileStore) CreatePoll(id string, amountOfProposals int, amountOfGrades int) (err error) {
+	if err := validatePoll(id, amountOfProposals, amountOfGrades); nil != err {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if _, exists := s.polls[id]; exists {
+		return fmt.Errorf("%w: %s", ErrPollExists, id)
+	}
+
+	temporary, err := ioutil.TempDir(s.directory, temporaryPrefix+id+"-")
+	if nil != err {
+		return err
+	}
+	defer func() {
+		if nil != err {
+			_ = os.RemoveAll(temporary)
+		}
+	}()
+	metadata, err := json.Marshal(&pollMetadata{AmountOfProposals: amountOfProposals, AmountOfGrades: amountOfGrades})
+	if nil != err {
+		return err
+	}
+	if err := s.writeFileAtomically(filepath.Join(temporary, pollFileName), metadata); nil != err {
+		return err
+	}
+	if err := s.writeFileAtomically(filepath.Join(temporary, walFileName), []byte{}); nil != err {
+		return err
+	}
+	pollDirectory := filepath.Join(s.directory, id)
+	if err := os.Rename(temporary, pollDirectory); nil != err {
+		return err
+	}
+	if err := s.syncDirectory(s.directory); nil != err {
+		return err
+	}
+
+	wal, err := os.OpenFile(filepath.Join(pollDirectory, walFileName), os.O_RDWR, 0644)
+	if nil != err {
+		return err
+	}
+	s.polls[id] = &filePoll{
+		directory: pollDirectory,
+		tally:     newTally(amountOfProposals, amountOfGrades),
+		wal:       wal,
+	}
+	return nil
+}
+
+// AppendJudgments is part of the Store interface.
+// The judgments are durable once it returns without error.
+func (s *FileStore) AppendJudgments(id string, judgments ...Judgment) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return err
+	}
+	if err := validateJudgments(poll.tally, judgments); nil != err {
+		return err
+	}
+	if 0 == len(judgments) {
+		return nil
+	}
+
+	record, err := encodeRecord(judgments)
+	if nil != err {
+		return err
+	}
+	if _, err := poll.wal.WriteAt(record, poll.walOffset); nil != err {
+		_ = poll.wal.Truncate(poll.walOffset)
+		return err
+	}
+	if !s.options.NoSync {
+		if err := poll.wal.Sync(); nil != err {
+			_ = poll.wal.Truncate(poll.walOffset)
+			return err
+		}
+	}
+	poll.walOffset += int64(len(record))
+	countJudgments(poll.tally, judgments)
+
+	poll.appendsSinceCheckpoint++
+	if poll.appendsSinceCheckpoint >= s.options.CheckpointInterval {
+		// The judgments are safe in the log already ; a failed checkpoint is retried on the next append.
+		_ = s.checkpoint(poll)
+	}
+	return nil
+}
+
+// LoadTally is part of the Store interface
+func (s *FileStore) LoadTally(id string) (*judgment.PollTally, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return nil, err
+	}
+	return poll.tally.Copy(), nil
+}
+
+// SaveResult is part of the Store interface
+func (s *FileStore) SaveResult(id string, result *judgment.PollResult) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return err
+	}
+	data, err := json.Marshal(result)
+	if nil != err {
+		return err
+	}
+	return s.writeFileAtomically(filepath.Join(poll.directory, resultFileName), data)
+}
+
+// LoadResult is part of the Store interface
+func (s *FileStore) LoadResult(id string) (*judgment.PollResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(poll.directory, resultFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if nil != err {
+		return nil, err
+	}
+	result := &judgment.PollResult{}
+	if err := json.Unmarshal(data, result); nil != err {
+		return nil, err
+	}
+	// ProposalsSorted shares its pointers with Proposals, like in results of Deliberate.
+	for position, proposalResult := range result.ProposalsSorted {
+		if proposalResult.Index >= 0 && proposalResult.Index < len(result.Proposals) {
+			result.ProposalsSorted[position] = result.Proposals[proposalResult.Index]
+		}
+	}
+	return result, nil
+}
+
+// Close checkpoints the polls with appends since their last checkpoint, and closes their logs.
+func (s *FileStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	var firstErr error
+	for _, poll := range s.polls {
+		if poll.appendsSinceCheckpoint > 0 {
+			if err := s.checkpoint(poll); nil != err && nil == firstErr {
+				firstErr = err
+			}
+		}
+		if err := poll.wal.Close(); nil != err && nil == firstErr {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// poll must be called with the mutex held.
+func (s *FileStore) poll(id string) (*filePoll, error) {
+	if s.closed {
+		return nil, ErrClosed
+	}
+	poll, exists := s.polls[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPollNotFound, id)
+	}
+	return poll, nil
+}
+
+func (s *FileStore) checkpoint(poll *filePoll) error {
+	data, err := json.Marshal(&checkpoint{WALOffset: poll.walOffset, Tally: poll.tally})
+	if nil != err {
+		return err
+	}
+	if err := s.writeFileAtomically(filepath.Join(poll.directory, checkpointFileName), data); nil != err {
+		return err
+	}
+	poll.appendsSinceCheckpoint = 0
+	return nil
+}
+
+// recoverPoll rebuilds the tally of the poll in directory from its checkpoint, if any, and the rest of its log.
+func (s *FileStore) recoverPoll(directory string) (_ *filePoll, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(directory, pollFileName))
+	if nil != err {
+		return nil, err
+	}
+	metadata := &pollMetadata{}
+	if err := json.Unmarshal(data, metadata); nil != err {
+		return nil, err
+	}
+	if err := validatePoll(filepath.Base(directory), metadata.AmountOfProposals, metadata.AmountOfGrades); nil != err {
+		return nil, err
+	}
+
+	poll := &filePoll{directory: directory, tally: newTally(metadata.AmountOfProposals, metadata.AmountOfGrades)}
+	data, err = ioutil.ReadFile(filepath.Join(directory, checkpointFileName))
+	if nil == err {
+		saved := &checkpoint{}
+		if err := json.Unmarshal(data, saved); nil != err {
+			return nil, fmt.Errorf("corrupted checkpoint: %w", err)
+		}
+		if !hasShape(saved.Tally, metadata) {
+			return nil, errors.New("corrupted checkpoint: the tally does not match the poll")
+		}
+		poll.tally = saved.Tally
+		poll.walOffset = saved.WALOffset
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	poll.wal, err = os.OpenFile(filepath.Join(directory, walFileName), os.O_RDWR, 0644)
+	if nil != err {
+		return nil, err
+	}
+	defer func() {
+		if nil != err {
+			_ = poll.wal.Close()
+		}
+	}()
+	info, err := poll.wal.Stat()
+	if nil != err {
+		return nil, err
+	}
+	if poll.walOffset > info.Size() {
+		return nil, errors.New("corrupted checkpoint: it accounts for more than the log holds")
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(poll.wal, poll.walOffset, info.Size()-poll.walOffset))
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if io.EOF == readErr && 0 == len(line) {
+			break
+		}
+		if nil != readErr {
+			if io.EOF != readErr {
+				return nil, readErr
+			}
+			// Torn record, without its final newline: the append never returned, so we discard it.
+			if err := poll.wal.Truncate(poll.walOffset); nil != err {
+				return nil, err
+			}
+			break
+		}
+		judgments, err := decodeRecord(line)
+		if nil == err {
+			err = validateJudgments(poll.tally, judgments)
+		}
+		if nil != err {
+			// Whole records were synced, so we would rather not guess which judgments to drop.
+			return nil, fmt.Errorf("corrupted log at offset %d: %w", poll.walOffset, err)
+		}
+		countJudgments(poll.tally, judgments)
+		poll.walOffset += int64(len(line))
+		poll.appendsSinceCheckpoint++
+	}
+
+	return poll, nil
+}
+
+func hasShape(pollTally *judgment.PollTally, metadata *pollMetadata) bool {
+	if nil == pollTally || metadata.AmountOfProposals != len(pollTally.Proposals) {
+		return false
+	}
+	for _, proposalTally := range pollTally.Proposals {
+		if nil == proposalTally || metadata.AmountOfGrades != len(proposalTally.Tally) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeRecord writes judgments as a line of the log: the CRC-32 of the JSON, a space, the JSON.
+func encodeRecord(judgments []Judgment) ([]byte, error) {
+	data, err := json.Marshal(judgments)
+	if nil != err {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE(data), data)), nil
+}
+
+func decodeRecord(line []byte) ([]Judgment, error) {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	if len(line) < 10 || ' ' != line[8] {
+		return nil, errors.New("malformed record")
+	}
+	var checksum uint32
+	if _, err := fmt.Sscanf(string(line[:8]), "%08x", &checksum); nil != err {
+		return nil, err
+	}
+	data := line[9:]
+	if checksum != crc32.ChecksumIEEE(data) {
+		return nil, errors.New("checksum mismatch")
+	}
+	judgments := []Judgment{}
+	if err := json.Unmarshal(data, &judgments); nil != err {
+		return nil, err
+	}
+	return judgments, nil
+}
+
+// writeFileAtomically writes to a temporary file, syncs it, and renames it over path.
+func (s *FileStore) writeFileAtomically(path string, data []byte) (err error) {
+	temporary := path + ".tmp"
+	file, err := os.OpenFile(temporary, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if nil != err {
+		return err
+	}
+	defer func() {
+		if nil != err {
+			_ = os.Remove(temporary)
+		}
+	}()
+	if _, err := file.Write(data); nil != err {
+		_ = file.Close()
+		return err
+	}
+	if !s.options.NoSync {
+		if err := file.Sync(); nil != err {
+			_ = file.Close()
+			return err
+		}
+	}
+	if err := file.Close(); nil != err {
+		return err
+	}
+	if err := os.Rename(temporary, path); nil != err {
+		return err
+	}
+	return s.syncDirectory(filepath.Dir(path))
+}
+
+func (s *FileStore) syncDirectory(directory string) error {
+	if s.options.NoSync {
+		return nil
+	}
+	file, err := os.Open(directory)
+	if nil != err {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openTestFileStore(t *testing.T, directory string, checkpointInterval int) *FileStore {
+	s, err := OpenFileStore(directory, &FileStoreOptions{CheckpointInterval: checkpointInterval})
+	assert.NoError(t, err, "Opening the store should succeed")
+	return s
+}
+
+func TestFileStore_Restart(t *testing.T) {
+	directory := t.TempDir()
+	s := openTestFileStore(t, directory, 2)
+	assert.NoError(t, s.CreatePoll("lunch", 2, 3))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, s.AppendJudgments("lunch", Judgment{Proposal: i % 2, Grade: uint8(i % 3)}))
+	}
+	assert.FileExists(t, filepath.Join(directory, "lunch", checkpointFileName), "A checkpoint should be written every 2 appends")
+	// Simulate a crash: no Close, so the last append is only in the log.
+
+	restarted := openTestFileStore(t, directory, 2)
+	pollTally, err := restarted.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 1, 1}, pollTally.Proposals[0].Tally)
+	assert.Equal(t, []uint64{1, 1, 0}, pollTally.Proposals[1].Tally)
+
+	assert.NoError(t, restarted.AppendJudgments("lunch", Judgment{Proposal: 1, Grade: 2}))
+	assert.NoError(t, restarted.Close())
+	assert.NoError(t, s.Close())
+
+	again := openTestFileStore(t, directory, 2)
+	pollTally, err = again.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 1, 1}, pollTally.Proposals[1].Tally)
+	assert.NoError(t, again.Close())
+}
+
+func TestFileStore_TornRecord(t *testing.T) {
+	directory := t.TempDir()
+	s := openTestFileStore(t, directory, 100)
+	assert.NoError(t, s.CreatePoll("lunch", 1, 2))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Grade: 1}))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Grade: 0}))
+
+	walPath := filepath.Join(directory, "lunch", walFileName)
+	info, err := os.Stat(walPath)
+	assert.NoError(t, err)
+	// A crash in the middle of the third append
+	wal, err := os.OpenFile(walPath, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	_, err = wal.WriteString(`0badc0de [{"proposal":0,"gra`)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Close())
+
+	restarted := openTestFileStore(t, directory, 100)
+	pollTally, err := restarted.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 1}, pollTally.Proposals[0].Tally)
+	truncated, err := os.Stat(walPath)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Size(), truncated.Size(), "The torn record should be discarded")
+
+	assert.NoError(t, restarted.AppendJudgments("lunch", Judgment{Grade: 1}))
+	assert.NoError(t, restarted.Close())
+	again := openTestFileStore(t, directory, 100)
+	pollTally, _ = again.LoadTally("lunch")
+	assert.Equal(t, []uint64{1, 2}, pollTally.Proposals[0].Tally)
+	assert.NoError(t, again.Close())
+}
+
+func TestFileStore_CorruptedRecord(t *testing.T) {
+	directory := t.TempDir()
+	s := openTestFileStore(t, directory, 100)
+	assert.NoError(t, s.CreatePoll("lunch", 1, 2))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Grade: 1}))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Grade: 1}))
+
+	walPath := filepath.Join(directory, "lunch", walFileName)
+	data, err := ioutil.ReadFile(walPath)
+	assert.NoError(t, err)
+	// No Close, which would checkpoint the tally: the log must be replayed.
+	recordLength := len(data) / 2
+	for _, offset := range []int{len(data) - 4, recordLength - 4} {
+		corrupted := append([]byte{}, data...)
+		corrupted[offset] = '0' // the grade of a record, without fixing its checksum
+		assert.NoError(t, ioutil.WriteFile(walPath, corrupted, 0644))
+
+		_, err = OpenFileStore(directory, nil)
+		if assert.Error(t, err, "Records failing their checksum should fail the opening") {
+			assert.Contains(t, err.Error(), "corrupted log")
+		}
+		kept, err := ioutil.ReadFile(walPath)
+		assert.NoError(t, err)
+		assert.Equal(t, corrupted, kept, "Synced records should not be discarded")
+	}
+}
+
+func TestFileStore_Leftovers(t *testing.T) {
+	directory := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(directory, temporaryPrefix+"lunch-123"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(directory, "notes.txt"), []byte("hello"), 0644))
+
+	s := openTestFileStore(t, directory, 100)
+	assert.NoDirExists(t, filepath.Join(directory, temporaryPrefix+"lunch-123"), "Aborted creations should be cleaned up")
+	assert.NoError(t, s.CreatePoll("lunch", 1, 1))
+	assert.NoError(t, s.Close())
+}
+
+func TestFileStore_CorruptedCheckpoint(t *testing.T) {
+	directory := t.TempDir()
+	s := openTestFileStore(t, directory, 1)
+	assert.NoError(t, s.CreatePoll("lunch", 1, 2))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Grade: 1}))
+	assert.NoError(t, s.Close())
+
+	checkpointPath := filepath.Join(directory, "lunch", checkpointFileName)
+	for _, content := range []string{
+		`{"walOffset": 0, "tally": {"proposals": [{"tally": [1, 2, 3]}]}}`,
+		`{"walOffset": 9999, "tally": {"proposals": [{"tally": [1, 2]}]}}`,
+		`{`,
+	} {
+		assert.NoError(t, ioutil.WriteFile(checkpointPath, []byte(content), 0644))
+		_, err := OpenFileStore(directory, nil)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestRecord_RoundTrip(t *testing.T) {
+	judgments := []Judgment{{Proposal: 3, Grade: 255}, {Proposal: 0, Grade: 0}}
+	record, err := encodeRecord(judgments)
+	assert.NoError(t, err)
+	assert.Equal(t, byte('\n'), record[len(record)-1])
+	decoded, err := decodeRecord(record)
+	assert.NoError(t, err)
+	assert.Equal(t, judgments, decoded)
+
+	for _, line := range []string{"", "short\n", "zzzzzzzz []\n", "00000000 []\n"} {
+		_, err := decodeRecord([]byte(line))
+		assert.Error(t, err, line)
+	}
+}
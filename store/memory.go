@@ -0,0 +1,110 @@
+package store
+
+import (
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"sync"
+)
+
+// MemoryStore keeps polls in memory ; they are lost when the process exits.
+type MemoryStore struct {
+	mutex  sync.Mutex
+	polls  map[string]*memoryPoll
+	closed bool
+}
+
+type memoryPoll struct {
+	tally  *judgment.PollTally
+	result *judgment.PollResult
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{polls: map[string]*memoryPoll{}}
+}
+
+// CreatePoll is part of the Store interface
+func (s *MemoryStore) CreatePoll(id string, amountOfProposals int, amountOfGrades int) error {
+	if err := validatePoll(id, amountOfProposals, amountOfGrades); nil != err {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if _, exists := s.polls[id]; exists {
+		return fmt.Errorf("%w: %s", ErrPollExists, id)
+	}
+	s.polls[id] = &memoryPoll{tally: newTally(amountOfProposals, amountOfGrades)}
+	return nil
+}
+
+// AppendJudgments is part of the Store interface
+func (s *MemoryStore) AppendJudgments(id string, judgments ...Judgment) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return err
+	}
+	if err := validateJudgments(poll.tally, judgments); nil != err {
+		return err
+	}
+	countJudgments(poll.tally, judgments)
+	return nil
+}
+
+// LoadTally is part of the Store interface
+func (s *MemoryStore) LoadTally(id string) (*judgment.PollTally, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return nil, err
+	}
+	return poll.tally.Copy(), nil
+}
+
+// SaveResult is part of the Store interface
+func (s *MemoryStore) SaveResult(id string, result *judgment.PollResult) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return err
+	}
+	poll.result = result
+	return nil
+}
+
+// LoadResult is part of the Store interface
+func (s *MemoryStore) LoadResult(id string) (*judgment.PollResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	poll, err := s.poll(id)
+	if nil != err {
+		return nil, err
+	}
+	return poll.result, nil
+}
+
+// Close is part of the Store interface
+func (s *MemoryStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	return nil
+}
+
+// poll must be called with the mutex held.
+func (s *MemoryStore) poll(id string) (*memoryPoll, error) {
+	if s.closed {
+		return nil, ErrClosed
+	}
+	poll, exists := s.polls[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPollNotFound, id)
+	}
+	return poll, nil
+}
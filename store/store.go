@@ -0,0 +1,106 @@
+// Package store persists polls: their judgments as they come, and snapshots of their results.
+//
+// MemoryStore is handy for tests and ephemeral servers, and FileStore survives crashes
+// by appending judgments to a write-ahead log, checkpointing the tallies now and then.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"math"
+)
+
+// Errors returned by the stores, wrapped with more context.
+// Use errors.Is() to tell them apart.
+var (
+	// ErrPollNotFound is returned when no poll has the requested identifier.
+	ErrPollNotFound = errors.New("poll not found")
+	// ErrPollExists is returned when creating a poll with an identifier already in use.
+	ErrPollExists = errors.New("poll already exists")
+	// ErrInvalidPoll is returned for malformed identifiers or shapes of polls.
+	ErrInvalidPoll = errors.New("invalid poll")
+	// ErrInvalidJudgment is returned when a judgment targets a proposal or grade the poll does not have.
+	ErrInvalidJudgment = errors.New("invalid judgment")
+	// ErrClosed is returned when using a store after closing it.
+	ErrClosed = errors.New("store is closed")
+)
+
+// maximumIDLength keeps identifiers usable as file names
+const maximumIDLength = 64
+
+// Judgment is the grade given by a judge to a proposal.
+type Judgment struct {
+	Proposal int   `json:"proposal"` // index of the proposal in the poll
+	Grade    uint8 `json:"grade"`    // 0 == "worst" grade
+}
+
+// Store persists polls.  Implementations are safe for concurrent use.
+type Store interface {
+	// CreatePoll registers a poll with an empty tally.
+	CreatePoll(id string, amountOfProposals int, amountOfGrades int) error
+	// AppendJudgments counts judgments in the tally of the poll, all or none of them.
+	AppendJudgments(id string, judgments ...Judgment) error
+	// LoadTally returns a copy of the tally of the poll.
+	// Its AmountOfJudges is left to 0, since judgments are not tied to judges ; see PollTally.Balance.
+	LoadTally(id string) (*judgment.PollTally, error)
+	// SaveResult replaces the snapshot of the result of the poll.
+	SaveResult(id string, result *judgment.PollResult) error
+	// LoadResult returns the last snapshot of the result of the poll, or nil if there is none.
+	LoadResult(id string) (*judgment.PollResult, error)
+	// Close releases the resources of the store.
+	Close() error
+}
+
+// validatePoll checks the identifier and the shape of a new poll.
+// Identifiers are limited to letters, digits, - and _, so that they are safe as file names.
+func validatePoll(id string, amountOfProposals int, amountOfGrades int) error {
+	if "" == id || len(id) > maximumIDLength {
+		return fmt.Errorf("%w: identifiers hold between 1 and %d characters", ErrInvalidPoll, maximumIDLength)
+	}
+	for _, r := range id {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit && '-' != r && '_' != r {
+			return fmt.Errorf("%w: identifier %q holds %q ; use letters, digits, - and _", ErrInvalidPoll, id, r)
+		}
+	}
+	if amountOfProposals < 0 {
+		return fmt.Errorf("%w: negative amount of proposals", ErrInvalidPoll)
+	}
+	// Deliberation counts the grades of a proposal in a uint8.
+	if amountOfGrades < 1 || amountOfGrades > math.MaxUint8 {
+		return fmt.Errorf("%w: polls have between 1 and %d grades", ErrInvalidPoll, math.MaxUint8)
+	}
+	return nil
+}
+
+// newTally returns an empty tally of the given shape.
+func newTally(amountOfProposals int, amountOfGrades int) *judgment.PollTally {
+	pollTally := &judgment.PollTally{Proposals: make([]*judgment.ProposalTally, 0, amountOfProposals)}
+	for proposalIndex := 0; proposalIndex < amountOfProposals; proposalIndex++ {
+		pollTally.Proposals = append(pollTally.Proposals, &judgment.ProposalTally{Tally: make([]uint64, amountOfGrades)})
+	}
+	return pollTally
+}
+
+// validateJudgments makes sure that all the judgments fit in the tally, before counting any.
+func validateJudgments(pollTally *judgment.PollTally, judgments []Judgment) error {
+	for judgmentIndex, j := range judgments {
+		if j.Proposal < 0 || j.Proposal >= len(pollTally.Proposals) {
+			return fmt.Errorf("%w: judgment #%d targets proposal #%d, but there are %d proposals",
+				ErrInvalidJudgment, judgmentIndex, j.Proposal, len(pollTally.Proposals))
+		}
+		if int(j.Grade) >= len(pollTally.Proposals[j.Proposal].Tally) {
+			return fmt.Errorf("%w: judgment #%d gives grade %d, but there are %d grades",
+				ErrInvalidJudgment, judgmentIndex, j.Grade, len(pollTally.Proposals[j.Proposal].Tally))
+		}
+	}
+	return nil
+}
+
+func countJudgments(pollTally *judgment.PollTally, judgments []Judgment) {
+	for _, j := range judgments {
+		pollTally.Proposals[j.Proposal].Tally[j.Grade]++
+	}
+}
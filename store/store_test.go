@@ -0,0 +1,91 @@
+package store
+
+import (
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+// testStore checks the behavior that all implementations of Store share.
+func testStore(t *testing.T, s Store) {
+	assert.NoError(t, s.CreatePoll("lunch", 3, 4), "Creating a poll should succeed")
+	assert.True(t, errors.Is(s.CreatePoll("lunch", 3, 4), ErrPollExists))
+	assert.True(t, errors.Is(s.CreatePoll("", 3, 4), ErrInvalidPoll))
+	assert.True(t, errors.Is(s.CreatePoll("../etc", 3, 4), ErrInvalidPoll))
+	assert.True(t, errors.Is(s.CreatePoll("dinner", 3, 0), ErrInvalidPoll))
+	assert.True(t, errors.Is(s.CreatePoll("dinner", 3, 256), ErrInvalidPoll), "256 grades would wrap around")
+	assert.NoError(t, s.CreatePoll("grades", 1, 255))
+	assert.True(t, errors.Is(s.CreatePoll("dinner", -1, 4), ErrInvalidPoll))
+
+	pollTally, err := s.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0, 0, 0, 0}, pollTally.Proposals[2].Tally)
+
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Proposal: 0, Grade: 3}, Judgment{Proposal: 2, Grade: 1}))
+	assert.NoError(t, s.AppendJudgments("lunch", Judgment{Proposal: 0, Grade: 3}))
+	assert.NoError(t, s.AppendJudgments("lunch"))
+	err = s.AppendJudgments("lunch", Judgment{Proposal: 1, Grade: 0}, Judgment{Proposal: 3, Grade: 0})
+	assert.True(t, errors.Is(err, ErrInvalidJudgment), "Unknown proposals should be refused")
+	err = s.AppendJudgments("lunch", Judgment{Proposal: 1, Grade: 4})
+	assert.True(t, errors.Is(err, ErrInvalidJudgment), "Unknown grades should be refused")
+	assert.True(t, errors.Is(s.AppendJudgments("brunch", Judgment{}), ErrPollNotFound))
+
+	pollTally, err = s.LoadTally("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0, 0, 0, 2}, pollTally.Proposals[0].Tally)
+	assert.Equal(t, []uint64{0, 0, 0, 0}, pollTally.Proposals[1].Tally, "Refused judgments should not be counted at all")
+	assert.Equal(t, []uint64{0, 1, 0, 0}, pollTally.Proposals[2].Tally)
+	pollTally.Proposals[0].Tally[0] = 42
+	again, _ := s.LoadTally("lunch")
+	assert.Equal(t, uint64(0), again.Proposals[0].Tally[0], "Loaded tallies should be copies")
+
+	result, err := s.LoadResult("lunch")
+	assert.NoError(t, err)
+	assert.Nil(t, result, "There should be no result yet")
+	balanced := again.Copy()
+	assert.NoError(t, balanced.Balance(&judgment.BalancingStrategy{Kind: judgment.BalancingStatic}))
+	result, err = (&judgment.MajorityJudgment{}).Deliberate(balanced)
+	assert.NoError(t, err)
+	assert.NoError(t, s.SaveResult("lunch", result))
+	loaded, err := s.LoadResult("lunch")
+	assert.NoError(t, err)
+	assert.Equal(t, result, loaded)
+	assert.True(t, loaded.ProposalsSorted[0] == loaded.Proposals[loaded.ProposalsSorted[0].Index])
+
+	_, err = s.LoadTally("brunch")
+	assert.True(t, errors.Is(err, ErrPollNotFound))
+	_, err = s.LoadResult("brunch")
+	assert.True(t, errors.Is(err, ErrPollNotFound))
+	assert.True(t, errors.Is(s.SaveResult("brunch", result), ErrPollNotFound))
+
+	assert.NoError(t, s.CreatePoll("concurrent", 2, 2))
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, s.AppendJudgments("concurrent", Judgment{Proposal: i % 2, Grade: 1}))
+		}(i)
+	}
+	wg.Wait()
+	pollTally, _ = s.LoadTally("concurrent")
+	assert.Equal(t, []uint64{0, 10}, pollTally.Proposals[1].Tally)
+
+	assert.NoError(t, s.Close())
+	assert.True(t, errors.Is(s.AppendJudgments("lunch", Judgment{}), ErrClosed))
+	_, err = s.LoadTally("lunch")
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.True(t, errors.Is(s.CreatePoll("dinner", 1, 1), ErrClosed))
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	s, err := OpenFileStore(t.TempDir(), &FileStoreOptions{CheckpointInterval: 3})
+	assert.NoError(t, err)
+	testStore(t, s)
+}
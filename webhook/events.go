@@ -0,0 +1,144 @@
+// Package webhook tells HTTP endpoints when the ranking of a poll changes.
+//
+// A Notifier compares successive results, turns their differences into events
+// (leader changed, rank changed, tie appeared, tie resolved), and POSTs each event as JSON,
+// signed with HMAC-SHA256, retrying with exponential backoff.
+package webhook
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"sort"
+	"time"
+)
+
+// EventType tells what changed in the ranking.
+type EventType string
+
+// Types of events
+const (
+	EventLeaderChanged EventType = "leader.changed" // the set of proposals ranked first changed
+	EventRankChanged   EventType = "rank.changed"   // the rank of a proposal changed
+	EventTieAppeared   EventType = "tie.appeared"   // some proposals now share a rank
+	EventTieResolved   EventType = "tie.resolved"   // some proposals no longer share a rank
+)
+
+// Event is the body of the requests of a Notifier.
+// Depending on the Type, some fields are left empty.
+type Event struct {
+	ID              string    `json:"id"`   // unique per Notifier, to deduplicate retried deliveries
+	Type            EventType `json:"type"` // tells which fields below are relevant
+	PollID          string    `json:"pollId,omitempty"`
+	Time            time.Time `json:"time"`
+	Proposal        *int      `json:"proposal,omitempty"`        // rank.changed
+	PreviousRank    int       `json:"previousRank,omitempty"`    // rank.changed
+	Rank            int       `json:"rank,omitempty"`            // rank.changed, tie.appeared, tie.resolved
+	Leaders         []int     `json:"leaders,omitempty"`         // leader.changed: indices of the proposals ranked first
+	PreviousLeaders []int     `json:"previousLeaders,omitempty"` // leader.changed
+	Proposals       []int     `json:"proposals,omitempty"`       // tie.appeared, tie.resolved: indices of the tied proposals
+}
+
+// Compare lists the events that happened between two results of the same poll, in a stable order:
+// leader changes first, then rank changes by proposal, then ties appearing, then ties resolving.
+// There are no events when previous is nil ; IDs, poll ID and times are left for the Notifier to fill.
+func Compare(previous *judgment.PollResult, current *judgment.PollResult) []Event {
+	events := []Event{}
+	if nil == previous || nil == current {
+		return events
+	}
+
+	previousLeaders := leadersOf(previous)
+	leaders := leadersOf(current)
+	if !sameInts(previousLeaders, leaders) {
+		events = append(events, Event{
+			Type:            EventLeaderChanged,
+			Leaders:         leaders,
+			PreviousLeaders: previousLeaders,
+		})
+	}
+
+	for proposalIndex, proposalResult := range current.Proposals {
+		if proposalIndex >= len(previous.Proposals) {
+			break
+		}
+		previousRank := previous.Proposals[proposalIndex].Rank
+		if previousRank != proposalResult.Rank {
+			index := proposalIndex
+			events = append(events, Event{
+				Type:         EventRankChanged,
+				Proposal:     &index,
+				PreviousRank: previousRank,
+				Rank:         proposalResult.Rank,
+			})
+		}
+	}
+
+	previousTies := tiesOf(previous)
+	ties := tiesOf(current)
+	for _, tie := range ties {
+		if !containsTie(previousTies, tie) {
+			events = append(events, Event{Type: EventTieAppeared, Rank: tie.rank, Proposals: tie.proposals})
+		}
+	}
+	for _, tie := range previousTies {
+		if !containsTie(ties, tie) {
+			events = append(events, Event{Type: EventTieResolved, Rank: tie.rank, Proposals: tie.proposals})
+		}
+	}
+
+	return events
+}
+
+// tie is a group of proposals sharing a rank.
+type tie struct {
+	rank      int
+	proposals []int // in increasing order
+}
+
+func leadersOf(result *judgment.PollResult) []int {
+	leaders := []int{}
+	for _, proposalResult := range result.Proposals {
+		if 1 == proposalResult.Rank {
+			leaders = append(leaders, proposalResult.Index)
+		}
+	}
+	return leaders
+}
+
+// tiesOf returns the ties of the result, by increasing rank.
+func tiesOf(result *judgment.PollResult) []tie {
+	byRank := map[int][]int{}
+	for _, proposalResult := range result.Proposals {
+		byRank[proposalResult.Rank] = append(byRank[proposalResult.Rank], proposalResult.Index)
+	}
+	ties := []tie{}
+	for rank, proposals := range byRank {
+		if len(proposals) > 1 {
+			sort.Ints(proposals)
+			ties = append(ties, tie{rank: rank, proposals: proposals})
+		}
+	}
+	sort.Slice(ties, func(i, j int) bool { return ties[i].rank < ties[j].rank })
+	return ties
+}
+
+// containsTie tells whether the same proposals are tied, whatever their rank.
+func containsTie(ties []tie, needle tie) bool {
+	for _, candidate := range ties {
+		if sameInts(candidate.proposals, needle.proposals) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
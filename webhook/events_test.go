@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// resultWithRanks makes up a result with the given ranks, in the order of the proposals.
+func resultWithRanks(ranks ...int) *judgment.PollResult {
+	result := &judgment.PollResult{}
+	for index, rank := range ranks {
+		result.Proposals = append(result.Proposals, &judgment.ProposalResult{Index: index, Rank: rank})
+	}
+	return result
+}
+
+func TestCompare(t *testing.T) {
+	events := Compare(resultWithRanks(1, 2, 3), resultWithRanks(2, 1, 3))
+	assert.Len(t, events, 3)
+	assert.Equal(t, EventLeaderChanged, events[0].Type)
+	assert.Equal(t, []int{1}, events[0].Leaders)
+	assert.Equal(t, []int{0}, events[0].PreviousLeaders)
+	assert.Equal(t, EventRankChanged, events[1].Type)
+	assert.Equal(t, 0, *events[1].Proposal)
+	assert.Equal(t, 1, events[1].PreviousRank)
+	assert.Equal(t, 2, events[1].Rank)
+	assert.Equal(t, 1, *events[2].Proposal)
+}
+
+func TestCompare_Ties(t *testing.T) {
+	events := Compare(resultWithRanks(1, 2, 3), resultWithRanks(1, 2, 2))
+	assert.Len(t, events, 2)
+	assert.Equal(t, EventRankChanged, events[0].Type)
+	assert.Equal(t, EventTieAppeared, events[1].Type)
+	assert.Equal(t, 2, events[1].Rank)
+	assert.Equal(t, []int{1, 2}, events[1].Proposals)
+
+	events = Compare(resultWithRanks(1, 1, 3), resultWithRanks(1, 2, 3))
+	assert.Len(t, events, 3)
+	assert.Equal(t, EventLeaderChanged, events[0].Type)
+	assert.Equal(t, []int{0}, events[0].Leaders)
+	assert.Equal(t, EventRankChanged, events[1].Type)
+	assert.Equal(t, EventTieResolved, events[2].Type)
+	assert.Equal(t, []int{0, 1}, events[2].Proposals)
+
+	events = Compare(resultWithRanks(1, 2, 2), resultWithRanks(2, 1, 1))
+	for _, event := range events {
+		assert.NotEqual(t, EventTieAppeared, event.Type, "A tie moving rank is the same tie")
+		assert.NotEqual(t, EventTieResolved, event.Type, "A tie moving rank is the same tie")
+	}
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	assert.Empty(t, Compare(resultWithRanks(1, 1, 3), resultWithRanks(1, 1, 3)))
+	assert.Empty(t, Compare(nil, resultWithRanks(1, 2)), "There should be no events without a previous result")
+	assert.Empty(t, Compare(resultWithRanks(1, 2), nil))
+}
@@ -0,0 +1,262 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/mieuxvoter/majority-judgment-library-go/live"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers of our requests
+const (
+	HeaderEventID   = "X-MJ-Event-Id"
+	HeaderEventType = "X-MJ-Event-Type"
+	HeaderTimestamp = "X-MJ-Timestamp" // in seconds since the Unix epoch
+	HeaderSignature = "X-MJ-Signature" // "sha256=" followed by the hexadecimal HMAC, see Sign
+)
+
+// Defaults of the Notifier
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+	defaultTimeout        = 10 * time.Second
+)
+
+// Endpoint is an URL receiving our events, and the secret shared with it to sign them.
+type Endpoint struct {
+	URL    string
+	Secret []byte
+}
+
+// Notifier sends the events of a poll to its endpoints.  Its zero value is usable, once given endpoints.
+// Notify and Watch must not be called concurrently, since the notifier remembers the previous result.
+type Notifier struct {
+	PollID         string
+	Endpoints      []Endpoint
+	Client         *http.Client  // defaults to a client with a timeout of 10s
+	MaxAttempts    int           // per event and endpoint ; defaults to 5
+	InitialBackoff time.Duration // doubles after each failed attempt ; defaults to 500ms
+	MaxBackoff     time.Duration // defaults to 30s
+
+	previous *judgment.PollResult
+	sequence uint64
+	now      func() time.Time // for tests
+}
+
+// DeliveryError tells which event could not be delivered to which endpoint.
+type DeliveryError struct {
+	URL      string
+	EventID  string
+	Attempts int
+	Err      error
+}
+
+// Error is part of the error interface
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("webhook: event %s not delivered to %s after %d attempt(s): %v", e.EventID, e.URL, e.Attempts, e.Err)
+}
+
+// Unwrap allows errors.Is and errors.As on the last error
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// DeliveryErrors gathers the failed deliveries of a call to Notify or Send.
+type DeliveryErrors []*DeliveryError
+
+// Error is part of the error interface
+func (errs DeliveryErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Notify compares the result with the previous one, and sends the events in between.
+// The first call only remembers the result.
+func (notifier *Notifier) Notify(ctx context.Context, result *judgment.PollResult) error {
+	events := Compare(notifier.previous, result)
+	notifier.previous = result
+	if 0 == len(events) {
+		return nil
+	}
+	return notifier.Send(ctx, events)
+}
+
+// Watch notifies each update of a live poll, until the channel is closed or the context is done.
+// Delivery errors are given to onError, which may be nil.
+func (notifier *Notifier) Watch(ctx context.Context, updates <-chan live.Update, onError func(error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, open := <-updates:
+			if !open {
+				return
+			}
+			if err := notifier.Notify(ctx, update.Result); nil != err && nil != onError {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Send fills the ID, poll ID and time of the events, and sends them in order to all endpoints concurrently.
+// An endpoint failing an event still receives the next ones.
+func (notifier *Notifier) Send(ctx context.Context, events []Event) error {
+	now := time.Now
+	if nil != notifier.now {
+		now = notifier.now
+	}
+	bodies := make([][]byte, 0, len(events))
+	for eventIndex := range events {
+		notifier.sequence++
+		event := &events[eventIndex]
+		event.ID = fmt.Sprintf("%s-%d-%d", notifier.PollID, now().UnixNano(), notifier.sequence)
+		event.PollID = notifier.PollID
+		event.Time = now().UTC()
+		body, err := json.Marshal(event)
+		if nil != err {
+			return err
+		}
+		bodies = append(bodies, body)
+	}
+
+	mutex := sync.Mutex{}
+	errs := DeliveryErrors{}
+	wg := sync.WaitGroup{}
+	for _, endpoint := range notifier.Endpoints {
+		wg.Add(1)
+		go func(endpoint Endpoint) {
+			defer wg.Done()
+			for eventIndex, event := range events {
+				if err := notifier.deliver(ctx, endpoint, event, bodies[eventIndex]); nil != err {
+					mutex.Lock()
+					errs = append(errs, err)
+					mutex.Unlock()
+				}
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+
+	if 0 != len(errs) {
+		return errs
+	}
+	return nil
+}
+
+// deliver POSTs an event to an endpoint, retrying on network errors, 429 and 5xx responses.
+func (notifier *Notifier) deliver(ctx context.Context, endpoint Endpoint, event Event, body []byte) *DeliveryError {
+	maxAttempts := notifier.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	backoff := notifier.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+	maxBackoff := notifier.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	var err error
+	var retry bool
+	attempt := 1
+	for ; ; attempt++ {
+		retry, err = notifier.post(ctx, endpoint, event, body)
+		if nil == err || !retry || attempt >= maxAttempts {
+			break
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &DeliveryError{URL: endpoint.URL, EventID: event.ID, Attempts: attempt, Err: ctx.Err()}
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+	if nil != err {
+		return &DeliveryError{URL: endpoint.URL, EventID: event.ID, Attempts: attempt, Err: err}
+	}
+	return nil
+}
+
+// post makes a single attempt, and tells whether it is worth retrying when it fails.
+func (notifier *Notifier) post(ctx context.Context, endpoint Endpoint, event Event, body []byte) (retry bool, err error) {
+	request, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if nil != err {
+		return false, err
+	}
+	request = request.WithContext(ctx)
+	timestamp := strconv.FormatInt(event.Time.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEventID, event.ID)
+	request.Header.Set(HeaderEventType, string(event.Type))
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, body))
+
+	client := notifier.Client
+	if nil == client {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	response, err := client.Do(request)
+	if nil != err {
+		return nil == ctx.Err(), err
+	}
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(response.Body, 1<<16))
+	_ = response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retry = http.StatusTooManyRequests == response.StatusCode || response.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %s", response.Status)
+}
+
+// Sign returns the value of the signature header: "sha256=" followed by the hexadecimal
+// HMAC-SHA256, keyed with the secret, of the timestamp header, a dot, and the body.
+// Signing the timestamp lets receivers refuse replayed requests.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tells whether the signature of a request is valid, and its timestamp within tolerance of now.
+// Receivers should read the body beforehand, and give it here.
+func Verify(secret []byte, header http.Header, body []byte, tolerance time.Duration, now time.Time) bool {
+	timestamp := header.Get(HeaderTimestamp)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if nil != err {
+		return false
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(header.Get(HeaderSignature)))
+}
@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/mieuxvoter/majority-judgment-library-go/judgment"
+	"github.com/mieuxvoter/majority-judgment-library-go/live"
+	"github.com/mieuxvoter/majority-judgment-library-go/store"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("correct horse battery staple")
+
+// receiver records the events it receives, after failing the first failures requests with status.
+type receiver struct {
+	mutex    sync.Mutex
+	events   []Event
+	requests int
+	failures int
+	status   int
+	invalid  int // requests with an invalid signature
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	body, _ := ioutil.ReadAll(request.Body)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests++
+	if !Verify(testSecret, request.Header, body, time.Minute, time.Now()) {
+		r.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.requests <= r.failures {
+		w.WriteHeader(r.status)
+		return
+	}
+	event := Event{}
+	_ = json.Unmarshal(body, &event)
+	r.events = append(r.events, event)
+}
+
+func newTestNotifier(urls ...string) *Notifier {
+	notifier := &Notifier{PollID: "lunch", InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	for _, url := range urls {
+		notifier.Endpoints = append(notifier.Endpoints, Endpoint{URL: url, Secret: testSecret})
+	}
+	return notifier
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	defer server.Close()
+	notifier := newTestNotifier(server.URL)
+
+	assert.NoError(t, notifier.Notify(context.Background(), resultWithRanks(1, 2)))
+	assert.Equal(t, 0, r.requests, "The first result should only be remembered")
+	assert.NoError(t, notifier.Notify(context.Background(), resultWithRanks(2, 1)))
+	assert.NoError(t, notifier.Notify(context.Background(), resultWithRanks(2, 1)))
+
+	assert.Equal(t, 0, r.invalid)
+	assert.Len(t, r.events, 3)
+	assert.Equal(t, EventLeaderChanged, r.events[0].Type)
+	assert.Equal(t, "lunch", r.events[0].PollID)
+	assert.NotEqual(t, r.events[0].ID, r.events[1].ID, "Events should have unique IDs")
+	assert.Equal(t, EventRankChanged, r.events[2].Type)
+}
+
+func TestNotifier_Retries(t *testing.T) {
+	flaky := &receiver{failures: 2, status: http.StatusServiceUnavailable}
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+	steady := &receiver{}
+	steadyServer := httptest.NewServer(steady)
+	defer steadyServer.Close()
+
+	notifier := newTestNotifier(flakyServer.URL, steadyServer.URL)
+	assert.NoError(t, notifier.Send(context.Background(), []Event{{Type: EventTieAppeared, Rank: 1, Proposals: []int{0, 1}}}))
+	assert.Equal(t, 3, flaky.requests, "Failed deliveries should be retried")
+	assert.Len(t, flaky.events, 1)
+	assert.Equal(t, 1, steady.requests)
+}
+
+func TestNotifier_GivesUp(t *testing.T) {
+	down := &receiver{failures: 100, status: http.StatusBadGateway}
+	downServer := httptest.NewServer(down)
+	defer downServer.Close()
+	refusing := &receiver{failures: 100, status: http.StatusBadRequest}
+	refusingServer := httptest.NewServer(refusing)
+	defer refusingServer.Close()
+
+	notifier := newTestNotifier(downServer.URL, refusingServer.URL)
+	notifier.MaxAttempts = 3
+	err := notifier.Send(context.Background(), []Event{{Type: EventLeaderChanged}, {Type: EventLeaderChanged}})
+	assert.Error(t, err)
+
+	errs := DeliveryErrors{}
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 4, "Each event should fail on each endpoint")
+	assert.Equal(t, 6, down.requests, "Server errors should be retried up to MaxAttempts")
+	assert.Equal(t, 2, refusing.requests, "Client errors should not be retried")
+	assert.Contains(t, err.Error(), "after 3 attempt(s)")
+}
+
+func TestNotifier_Canceled(t *testing.T) {
+	down := &receiver{failures: 100, status: http.StatusInternalServerError}
+	server := httptest.NewServer(down)
+	defer server.Close()
+
+	notifier := newTestNotifier(server.URL)
+	notifier.InitialBackoff = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	err := notifier.Send(ctx, []Event{{Type: EventLeaderChanged}})
+	errs := DeliveryErrors{}
+	assert.True(t, errors.As(err, &errs))
+	assert.True(t, errors.Is(errs[0], context.Canceled), "Backoff should stop with the context")
+}
+
+func TestNotifier_Watch(t *testing.T) {
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	pollTally := &judgment.PollTally{Proposals: []*judgment.ProposalTally{{Tally: []uint64{0, 0}}, {Tally: []uint64{0, 0}}}}
+	poll, err := live.NewPoll(pollTally, &judgment.BalancingStrategy{Kind: judgment.BalancingStatic})
+	assert.NoError(t, err)
+	updates, unsubscribe := poll.Subscribe()
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		newTestNotifier(server.URL).Watch(context.Background(), updates, func(err error) { t.Error(err) })
+		close(done)
+	}()
+	// Wait for the notifier to take the initial result, so that it compares against it.
+	for 0 != len(updates) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.NoError(t, poll.AddJudgments(store.Judgment{Proposal: 1, Grade: 1}))
+	poll.Close()
+	<-done
+
+	assert.NotEmpty(t, r.events)
+	assert.Equal(t, EventLeaderChanged, r.events[0].Type)
+	assert.Equal(t, []int{1}, r.events[0].Leaders)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"leader.changed"}`)
+	header := http.Header{}
+	header.Set(HeaderTimestamp, "1700000000")
+	header.Set(HeaderSignature, Sign(testSecret, "1700000000", body))
+
+	assert.True(t, Verify(testSecret, header, body, time.Minute, now))
+	assert.True(t, Verify(testSecret, header, body, time.Minute, now.Add(30*time.Second)))
+	assert.False(t, Verify(testSecret, header, body, time.Minute, now.Add(2*time.Minute)), "Old requests should be refused")
+	assert.False(t, Verify([]byte("wrong"), header, body, time.Minute, now))
+	assert.False(t, Verify(testSecret, header, []byte(`{"type":"tie.appeared"}`), time.Minute, now))
+
+	header.Set(HeaderTimestamp, "1700000001")
+	assert.False(t, Verify(testSecret, header, body, time.Minute, now), "The timestamp should be signed")
+	header.Set(HeaderTimestamp, "soon")
+	assert.False(t, Verify(testSecret, header, body, time.Minute, now))
+}